@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/christian-lee/livesub/internal/transcript"
+)
+
+// export converts a transcript CSV into one subtitle file per language.
+func export(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: livesub export <transcript.csv> [-format srt|vtt|ass] [-lang en-US,source] [-shift seconds] [-dir dir]")
+	}
+	csvPath := args[0]
+
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "srt", "subtitle format: srt, vtt or ass")
+	langs := fs.String("lang", "", `comma-separated languages to export, "source" for the original text (default: all)`)
+	shift := fs.Float64("shift", 0, "seconds to move every cue (negative = earlier, to offset translation delay)")
+	dir := fs.String("dir", "", "output directory (default: next to the CSV)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if !slices.Contains(transcript.SubtitleFormats, *format) {
+		return fmt.Errorf("unknown format %q (srt, vtt, ass)", *format)
+	}
+
+	entries, err := transcript.ReadFile(csvPath)
+	if err != nil {
+		return err
+	}
+	available := transcript.Languages(entries)
+	selected := available
+	if *langs != "" {
+		selected = strings.Split(*langs, ",")
+		for _, l := range selected {
+			if !slices.Contains(available, l) {
+				return fmt.Errorf("language %q not in transcript (have: %s)", l, strings.Join(available, ", "))
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no subtitles in %s", csvPath)
+	}
+
+	outDir := *dir
+	if outDir == "" {
+		outDir = filepath.Dir(csvPath)
+	}
+	base := strings.TrimSuffix(filepath.Base(csvPath), filepath.Ext(csvPath))
+	offset := time.Duration(*shift * float64(time.Second))
+	for _, lang := range selected {
+		cues := transcript.Cues(entries, lang, offset)
+		path := filepath.Join(outDir, fmt.Sprintf("%s.%s.%s", base, lang, *format))
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = transcript.WriteSubtitles(f, *format, cues, base+" ("+lang+")")
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		fmt.Printf("%s: %d cues\n", path, len(cues))
+	}
+	return nil
+}
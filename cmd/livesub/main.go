@@ -34,6 +34,10 @@ func main() {
 	if len(os.Args) < 2 {
 		fmt.Println("Usage:")
 		fmt.Println("  livesub run [config]     Start monitoring & translating")
+		fmt.Println("  livesub replay <config> <streamer> <audio-file|pcm> [-speed N] [-out console|file] [-maxlen N] [-transcripts dir]")
+		fmt.Println("                           Run the pipeline on a recording, sending to a local sink")
+		fmt.Println("  livesub export <transcript.csv> [-format srt|vtt|ass] [-lang en-US,source] [-shift N] [-dir dir]")
+		fmt.Println("                           Convert a transcript into subtitle files, one per language")
 		os.Exit(1)
 	}
 
@@ -47,6 +51,16 @@ func main() {
 			slog.Error("run failed", "err", err)
 			os.Exit(1)
 		}
+	case "replay":
+		if err := replay(os.Args[2:]); err != nil {
+			slog.Error("replay failed", "err", err)
+			os.Exit(1)
+		}
+	case "export":
+		if err := export(os.Args[2:]); err != nil {
+			slog.Error("export failed", "err", err)
+			os.Exit(1)
+		}
 	default:
 		fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
 		os.Exit(1)
@@ -83,8 +97,8 @@ func run(cfgPath string) error {
 		cancel()
 	}()
 
-	// Init Gemini translator
-	translator, err := translate.NewGeminiTranslator(ctx, cfg.Translation.APIKey, cfg.Translation.Model)
+	// Init translation backends (default + named)
+	translator, err := newTranslators(ctx, cfg.Translation)
 	if err != nil {
 		return fmt.Errorf("init translator: %w", err)
 	}
@@ -112,7 +126,63 @@ func run(cfgPath string) error {
 		}
 	}
 
+	// Translation cache: in-memory LRU over the SQLite table
+	var cache *translate.Cache
+	if cfg.Translation.CacheSize > 0 {
+		cache = translate.NewCache(cfg.Translation.CacheSize, authStore)
+		translator.UseCache(cache)
+	}
+
 	// Sync DB accounts to bot pool
+	configBots := make(map[string]bool)
+	for _, bc := range cfg.Bots {
+		configBots[bc.Name] = true
+	}
+	var twitchMu sync.Mutex                // syncDBBots runs from the web server and background jobs
+	twitchCreds := make(map[string]string) // pool name → login+token of the running TwitchBot
+	syncTwitchBots := func() {
+		twitchMu.Lock()
+		defer twitchMu.Unlock()
+		accounts, err := authStore.ListTwitchAccounts()
+		if err != nil {
+			slog.Error("load twitch accounts from DB", "err", err)
+			return
+		}
+		keep := make(map[string]bool)
+		for _, a := range accounts {
+			if !a.Valid {
+				continue
+			}
+			creds := a.Login + "\x00" + a.Token
+			existing := pool.Get(a.Name)
+			if existing != nil {
+				if _, ok := existing.(*bot.TwitchBot); !ok {
+					slog.Warn("twitch account name already used by another bot", "account", a.Name)
+					continue
+				}
+			}
+			keep[a.Name] = true
+			if existing != nil && twitchCreds[a.Name] == creds {
+				continue
+			}
+			if existing != nil {
+				existing.(*bot.TwitchBot).Close()
+			}
+			pool.Add(bot.NewTwitchBot(a.Name, a.Login, a.Token, bot.WithTwitchServer(cfg.Twitch.Server, cfg.Twitch.Plain)))
+			pool.CredentialsChanged(a.Name)
+			twitchCreds[a.Name] = creds
+		}
+		for name := range twitchCreds {
+			if keep[name] {
+				continue
+			}
+			if tb, ok := pool.Get(name).(*bot.TwitchBot); ok {
+				tb.Close()
+				pool.Remove(name)
+			}
+			delete(twitchCreds, name)
+		}
+	}
 	syncDBBots := func() {
 		dbAccounts, err := authStore.ListBiliAccounts()
 		if err != nil {
@@ -121,22 +191,32 @@ func run(cfgPath string) error {
 		}
 		for _, a := range dbAccounts {
 			if !a.Valid {
+				// Logged-out accounts leave the rotation, unless the bot comes from the config file
+				if pool.Get(a.Name) != nil && !configBots[a.Name] {
+					pool.Remove(a.Name)
+					slog.Warn("removed invalid account from bot pool", "account", a.Name)
+				}
 				continue
 			}
 			existing := pool.Get(a.Name)
 			if existing != nil {
-				if bb, ok := existing.(*bot.BilibiliBot); ok {
-					bb.UpdateCredentials(a.SESSDATA, a.BiliJCT, a.UID, a.DanmakuMax)
+				if bb, ok := existing.(*bot.BilibiliBot); ok && bb.UpdateCredentials(a.SESSDATA, a.BiliJCT, a.UID, a.DanmakuMax) {
+					pool.CredentialsChanged(a.Name)
 				}
 			} else {
 				b := bot.NewBilibiliBot(a.Name, 0, a.SESSDATA, a.BiliJCT, a.UID, a.DanmakuMax)
 				pool.Add(b)
+				pool.CredentialsChanged(a.Name) // back after a re-login; health is kept across Remove
 			}
 		}
+		syncTwitchBots()
 		slog.Info("synced DB accounts to bot pool", "total_bots", len(pool.Names()))
 	}
 	syncDBBots()
 
+	// Refresh QR-login cookies before they expire and push them into the pool
+	go authStore.RunBiliCookieRefresh(ctx, syncDBBots)
+
 	// Transcript logger setup
 	transcriptDir := filepath.Join(filepath.Dir(cfgPath), "transcripts")
 
@@ -155,6 +235,8 @@ func run(cfgPath string) error {
 
 	// Register callbacks
 	webServer.OnAccountChange(syncDBBots)
+	go authStore.RunBiliValidityCheck(ctx, webServer.AccountValidityChanged)
+	webServer.SetTranslationCache(cache)
 
 	// Start danmaku command handlers for streamers with command_uids
 	cmdHandlers := make(map[int64]*command.Handler) // roomID → handler
@@ -308,6 +390,7 @@ func run(cfgPath string) error {
 
 					// Create controller for this streamer
 					ctrl := controller.New(pool, sc.Outputs, tlog, sc.RoomID)
+					ctrl.SetStreamerName(sc.Name)
 					webServer.SetController(sc.Name, ctrl) // sync pause state BEFORE start
 					ctrl.OnChange(func() { webServer.BroadcastStatus() })
 					ctrl.Start(streamCtx)
@@ -324,7 +407,16 @@ func run(cfgPath string) error {
 					}
 
 					// Create and run agent
-					a := agent.New(sc, translator, ctrl)
+					cur := hotCfg.Get()
+					a := agent.New(sc, translator, ctrl,
+						agent.WithSTT(cur.STT),
+						agent.WithContext(cur.Translation.ContextLines, cur.Translation.ContextTokens),
+						agent.WithGlossary(func() translate.Glossary { return loadGlossary(authStore, sc.RoomID) }),
+						agent.WithBatch(cur.Translation.Batch),
+						agent.WithInterim(func(text, lang string, final bool) {
+							webServer.BroadcastInterim(sc.Name, sc.RoomID, text, lang, final)
+						}),
+					)
 					if err := a.Run(streamCtx); err != nil {
 						slog.Error("stream ended", "name", sc.Name, "err", err)
 					}
@@ -369,6 +461,44 @@ func run(cfgPath string) error {
 	return ctx.Err()
 }
 
+// loadGlossary reads a room's glossary from the store.
+func loadGlossary(store *auth.Store, roomID int64) translate.Glossary {
+	entries, err := store.ListGlossary(roomID)
+	if err != nil {
+		slog.Warn("load glossary", "room", roomID, "err", err)
+		return nil
+	}
+	g := make(translate.Glossary, 0, len(entries))
+	for _, e := range entries {
+		g = append(g, translate.Term{
+			Source:     e.Source,
+			Target:     e.Target,
+			TargetLang: e.TargetLang,
+			Variants:   e.Variants,
+		})
+	}
+	return g
+}
+
+// newTranslators builds the translation backend registry from config.
+func newTranslators(ctx context.Context, tc config.TranslationConfig) (*translate.Registry, error) {
+	backends := make(map[string]translate.Options, len(tc.Backends))
+	for _, b := range tc.Backends {
+		backends[b.Name] = translate.Options{
+			Provider: b.Provider,
+			APIKey:   b.APIKey,
+			Model:    b.Model,
+			Endpoint: b.Endpoint,
+		}
+	}
+	return translate.NewRegistry(ctx, translate.Options{
+		Provider: tc.Provider,
+		APIKey:   tc.APIKey,
+		Model:    tc.Model,
+		Endpoint: tc.Endpoint,
+	}, backends)
+}
+
 func openBrowser(url string) {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
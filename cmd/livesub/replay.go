@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/christian-lee/livesub/internal/agent"
+	"github.com/christian-lee/livesub/internal/auth"
+	"github.com/christian-lee/livesub/internal/bot"
+	"github.com/christian-lee/livesub/internal/config"
+	"github.com/christian-lee/livesub/internal/controller"
+	"github.com/christian-lee/livesub/internal/transcript"
+	"github.com/christian-lee/livesub/internal/translate"
+)
+
+// replay feeds a recorded audio file through the normal
+// STT → translate → controller path, sending to a local sink.
+func replay(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("usage: livesub replay <config> <streamer> <audio-file|pcm> [-speed N] [-out console|file] [-maxlen N] [-transcripts dir]")
+	}
+	cfgPath, streamerName, audioPath := args[0], args[1], args[2]
+
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	speed := fs.Float64("speed", 1, "playback speed (1 = real time, 0 = as fast as possible)")
+	out := fs.String("out", "console", `"console" or a JSONL file path for sent messages`)
+	maxLen := fs.Int("maxlen", 0, "simulated max message length for splitting (0 = no limit)")
+	transcriptDir := fs.String("transcripts", "", "write a transcript CSV to this directory")
+	if err := fs.Parse(args[3:]); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	var sc *config.StreamerConfig
+	for i := range cfg.Streamers {
+		if cfg.Streamers[i].Name == streamerName || strconv.FormatInt(cfg.Streamers[i].RoomID, 10) == streamerName {
+			sc = &cfg.Streamers[i]
+			break
+		}
+	}
+	if sc == nil {
+		return fmt.Errorf("streamer %q not found in config", streamerName)
+	}
+	if _, err := os.Stat(audioPath); err != nil {
+		return fmt.Errorf("audio file: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	translator, err := newTranslators(ctx, cfg.Translation)
+	if err != nil {
+		return fmt.Errorf("init translator: %w", err)
+	}
+	defer translator.Close()
+
+	// Glossary comes from the same DB the service uses
+	authStore, err := auth.NewStore(filepath.Join(filepath.Dir(cfgPath), "users.db"))
+	if err != nil {
+		return fmt.Errorf("init auth store: %w", err)
+	}
+	defer authStore.Close()
+
+	// Every output goes to a console or JSONL file sink instead of its platform
+	platform := "console"
+	if *out != "console" {
+		platform = "file"
+	}
+	outputs := make([]config.OutputConfig, len(sc.Outputs))
+	for i, o := range sc.Outputs {
+		o.Platform = platform
+		o.Path = *out
+		if *maxLen > 0 {
+			o.MaxLen = *maxLen
+		}
+		outputs[i] = o
+	}
+	streamer := *sc
+	streamer.Outputs = outputs
+
+	var tlog *transcript.Logger
+	if *transcriptDir != "" {
+		if tlog, err = transcript.NewLogger(*transcriptDir, sc.RoomID, sc.Name); err != nil {
+			return fmt.Errorf("transcript logger: %w", err)
+		}
+		defer tlog.Close()
+	}
+
+	ctrl := controller.New(bot.NewPool(), streamer.Outputs, tlog, sc.RoomID)
+	ctrl.SetStreamerName(sc.Name)
+	ctrl.Start(ctx)
+
+	a := agent.New(streamer, translator, ctrl,
+		agent.WithSTT(cfg.STT),
+		agent.WithContext(cfg.Translation.ContextLines, cfg.Translation.ContextTokens),
+		agent.WithGlossary(func() translate.Glossary { return loadGlossary(authStore, sc.RoomID) }),
+		agent.WithBatch(cfg.Translation.Batch),
+		agent.WithAudioSource(func(ctx context.Context) (io.ReadCloser, error) {
+			return decodeAudio(ctx, audioPath, *speed)
+		}),
+	)
+
+	slog.Info("replaying", "streamer", sc.Name, "file", audioPath, "speed", *speed, "out", *out)
+	err = a.RunOnce(ctx)
+	ctrl.Stop() // flushes the delay queue
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	slog.Info("replay finished", "streamer", sc.Name)
+	return nil
+}
+
+// decodeAudio runs ffmpeg to turn path into 16kHz mono s16le PCM.
+// speed 1 reads at real time, >1 accelerates, 0 decodes as fast as possible.
+// Files ending in .pcm/.raw are taken as 16kHz mono s16le already.
+func decodeAudio(ctx context.Context, path string, speed float64) (io.ReadCloser, error) {
+	args := []string{"-hide_banner", "-loglevel", "error", "-nostdin"}
+	if speed > 0 {
+		args = append(args, "-readrate", strconv.FormatFloat(speed, 'f', -1, 64))
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pcm", ".raw":
+		args = append(args, "-f", "s16le", "-ar", "16000", "-ac", "1")
+	}
+	args = append(args, "-i", path, "-vn", "-f", "s16le", "-acodec", "pcm_s16le", "-ar", "16000", "-ac", "1", "pipe:1")
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start ffmpeg: %w", err)
+	}
+	return &ffmpegReader{ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// ffmpegReader stops ffmpeg when closed.
+type ffmpegReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (r *ffmpegReader) Close() error {
+	r.ReadCloser.Close()
+	r.cmd.Process.Kill() // no-op error if it already exited
+	r.cmd.Wait()
+	return nil
+}
@@ -18,17 +18,111 @@ import (
 // translations to the Controller.
 type Agent struct {
 	streamer   config.StreamerConfig
-	translator *translate.GeminiTranslator
+	sttCfg     config.STTConfig
+	translator *translate.Registry
 	ctrl       *controller.Controller
+
+	history *translate.History // rolling prompt context, nil = disabled
+	seq     int                // next sequence number, kept across pipeline restarts
+
+	filter *noiseFilter // drops noise finals before translation
+
+	glossary func() translate.Glossary // current glossary, nil = none
+	batch    bool                      // batched multi-language translation
+
+	onInterim func(text, lang string, final bool) // live STT preview, nil = none
+
+	audioSource func(ctx context.Context) (io.ReadCloser, error) // nil = live stream capture
 }
 
 // New creates a new Agent for a specific streamer.
-func New(streamer config.StreamerConfig, translator *translate.GeminiTranslator, ctrl *controller.Controller) *Agent {
-	return &Agent{
+func New(streamer config.StreamerConfig, translator *translate.Registry, ctrl *controller.Controller, opts ...Option) *Agent {
+	a := &Agent{
 		streamer:   streamer,
+		sttCfg:     config.STTConfig{Provider: "google"},
 		translator: translator,
 		ctrl:       ctrl,
+		filter:     newNoiseFilter(streamer.Filter, streamer.SourceLang),
+	}
+	for _, o := range opts {
+		o(a)
 	}
+	return a
+}
+
+// Option configures an Agent.
+type Option func(*Agent)
+
+// WithSTT sets the global STT config (provider, endpoint).
+// The streamer's stt_provider, if set, takes precedence over cfg.Provider.
+func WithSTT(cfg config.STTConfig) Option {
+	return func(a *Agent) {
+		a.sttCfg = cfg
+	}
+}
+
+// WithContext enables rolling translation context: up to lines previous
+// utterances, trimmed to roughly tokens tokens, are included in each prompt.
+// The context lives for one live session (one Run call).
+func WithContext(lines, tokens int) Option {
+	return func(a *Agent) {
+		if lines > 0 {
+			a.history = translate.NewHistory(lines, tokens)
+		}
+	}
+}
+
+// WithGlossary sets the glossary source. It is called for every utterance,
+// so glossary edits apply without restarting the stream.
+func WithGlossary(load func() translate.Glossary) Option {
+	return func(a *Agent) {
+		a.glossary = load
+	}
+}
+
+// WithBatch enables single-request translation into all target languages
+// for backends that support it.
+func WithBatch(enabled bool) Option {
+	return func(a *Agent) {
+		a.batch = enabled
+	}
+}
+
+// WithInterim sets a callback receiving every STT hypothesis: interim
+// ones while the streamer is speaking, then the final one replacing them.
+func WithInterim(fn func(text, lang string, final bool)) Option {
+	return func(a *Agent) {
+		a.onInterim = fn
+	}
+}
+
+// WithAudioSource replaces live stream capture with src (e.g. a recorded
+// file decoded by ffmpeg). src must yield 16kHz mono s16le PCM.
+func WithAudioSource(src func(ctx context.Context) (io.ReadCloser, error)) Option {
+	return func(a *Agent) {
+		a.audioSource = src
+	}
+}
+
+// newRecognizer creates an STT backend for the streamer's configured provider.
+func (a *Agent) newRecognizer(ctx context.Context) (stt.Recognizer, error) {
+	sc := a.streamer
+	provider := a.sttCfg.Provider
+	if sc.STTProvider != "" {
+		provider = sc.STTProvider
+	}
+	return stt.New(ctx, provider, stt.Options{
+		Language: sc.SourceLang,
+		AltLangs: sc.AltLangs,
+		Endpoint: a.sttCfg.Endpoint,
+
+		Phrases:         sc.STT.Phrases,
+		PhraseBoost:     sc.STT.Boost,
+		Model:           sc.STT.Model,
+		Enhanced:        sc.STT.Enhanced,
+		ProfanityFilter: sc.STT.ProfanityFilter,
+		WordTimeOffsets: sc.STT.WordTimeOffsets,
+	})
 }
 
 // Run starts the Agent pipeline: stream capture → STT → translate → controller.
@@ -36,6 +130,7 @@ func New(streamer config.StreamerConfig, translator *translate.GeminiTranslator,
 // Automatically restarts ffmpeg + STT if the audio stream dies.
 func (a *Agent) Run(ctx context.Context) error {
 	sc := a.streamer
+	defer a.history.Reset() // session over: don't carry context into the next one
 	backoff := time.Second
 	const maxBackoff = 30 * time.Second
 
@@ -64,27 +159,46 @@ func (a *Agent) Run(ctx context.Context) error {
 	}
 }
 
-// runPipeline runs one cycle of: get stream URL → ffmpeg capture → STT → translate.
-// Returns when the audio stream ends (ffmpeg dies) or ctx is cancelled.
-func (a *Agent) runPipeline(ctx context.Context) error {
+// RunOnce runs the pipeline a single time, without restarting, until the
+// audio source ends or ctx is cancelled. Used for replaying recordings.
+func (a *Agent) RunOnce(ctx context.Context) error {
+	defer a.history.Reset()
+	return a.runPipeline(ctx)
+}
+
+// openAudio returns the PCM audio source: the configured one, or the
+// live stream captured via ffmpeg.
+func (a *Agent) openAudio(ctx context.Context) (io.ReadCloser, error) {
+	if a.audioSource != nil {
+		return a.audioSource(ctx)
+	}
 	sc := a.streamer
 
-	// 1. Get live stream URL
+	// Get live stream URL
 	streamURL, err := stream.GetStreamURL(ctx, sc.RoomID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	slog.Info("got stream URL", "name", sc.Name, "room", sc.RoomID)
 
-	// 2. Audio capture via ffmpeg
-	audioReader, err := stream.CaptureAudio(ctx, streamURL, nil)
+	// Audio capture via ffmpeg
+	return stream.CaptureAudio(ctx, streamURL, nil)
+}
+
+// runPipeline runs one cycle of: audio capture → STT → translate.
+// Returns when the audio stream ends (ffmpeg dies) or ctx is cancelled.
+func (a *Agent) runPipeline(ctx context.Context) error {
+	sc := a.streamer
+
+	// 1-2. Audio source (live stream URL → ffmpeg capture by default)
+	audioReader, err := a.openAudio(ctx)
 	if err != nil {
 		return err
 	}
 	defer audioReader.Close()
 
 	// 3. STT client
-	sttClient, err := stt.NewGoogleSTT(ctx, sc.SourceLang, sc.AltLangs)
+	sttClient, err := a.newRecognizer(ctx)
 	if err != nil {
 		return err
 	}
@@ -127,7 +241,7 @@ func (a *Agent) runPipeline(ctx context.Context) error {
 			case <-ctx.Done():
 				return
 			}
-			newClient, err := stt.NewGoogleSTT(ctx, sc.SourceLang, sc.AltLangs)
+			newClient, err := a.newRecognizer(ctx)
 			if err != nil {
 				slog.Error("STT reconnect failed", "err", err)
 				return
@@ -148,9 +262,11 @@ func (a *Agent) runPipeline(ctx context.Context) error {
 	sem := make(chan struct{}, workerCount)
 	slog.Info("translation pool", "streamer", sc.Name, "outputs", len(sc.Outputs), "workers", workerCount)
 
-	seq := 0
 	var translateWg sync.WaitGroup
 	for result := range resultsCh {
+		if a.onInterim != nil {
+			a.onInterim(result.Text, result.Language, result.IsFinal)
+		}
 		if !result.IsFinal {
 			continue
 		}
@@ -162,16 +278,41 @@ func (a *Agent) runPipeline(ctx context.Context) error {
 			continue
 		}
 
-		currentSeq := seq
-		seq++
+		if reason := a.filter.check(result, time.Now()); reason != "" {
+			slog.Info("STT final filtered", "name", sc.Name, "reason", reason,
+				"conf", result.Confidence, "text", result.Text)
+			a.ctrl.RecordFiltered(result.Language, result.Text, result.Confidence, reason)
+			continue
+		}
+
+		currentSeq := a.seq
+		a.seq++
+		a.history.Add(currentSeq, result.Text)
+		var glossary translate.Glossary
+		if a.glossary != nil {
+			glossary = a.glossary()
+		}
 
+		u := controller.Utterance{
+			Seq:        currentSeq,
+			Text:       result.Text,
+			Lang:       result.Language,
+			Confidence: result.Confidence,
+			At:         time.Now(),
+		}
 		sem <- struct{}{} // acquire worker slot
 		translateWg.Add(1)
-		go func(s int, text, lang string) {
+		go func() {
 			defer func() { <-sem }() // release worker slot
 			defer translateWg.Done()
-			controller.TranslateAndSubmit(ctx, a.ctrl, a.translator, s, text, lang, sc.Outputs)
-		}(currentSeq, result.Text, result.Language)
+			controller.TranslateAndSubmit(ctx, a.ctrl, controller.TranslateOptions{
+				Translators: a.translator,
+				Translator:  sc.Translator,
+				History:     a.history,
+				Glossary:    glossary,
+				Batch:       a.batch,
+			}, u, sc.Outputs)
+		}()
 	}
 
 	translateWg.Wait()
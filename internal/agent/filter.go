@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/christian-lee/livesub/internal/config"
+	"github.com/christian-lee/livesub/internal/stt"
+)
+
+// Filter reasons, written to the transcript for dropped finals.
+const (
+	FilterLowConfidence = "low_confidence"
+	FilterTooShort      = "too_short"
+	FilterFiller        = "filler"
+	FilterDuplicate     = "duplicate"
+)
+
+// dedupeWindow bounds how far apart two identical finals may be to count
+// as a duplicate (STT reconnects often repeat the last utterance).
+const dedupeWindow = 10 * time.Second
+
+// defaultFillers are the built-in interjection and laughter lists,
+// keyed by base language. Japanese laughter is listed as runs of two and
+// three so any longer run matches while a lone kana (は, へ...) stays a
+// reply; single kana can be added per streamer in fillers.
+var defaultFillers = map[string][]string{
+	"ja": {"えー", "えっと", "えーと", "あー", "うん", "んー", "まあ", "あの", "はは", "ははは", "ハハ", "ハハハ", "ふふ", "ふふふ", "へへ", "へへへ", "w", "ｗ", "笑", "草"},
+	"zh": {"嗯", "啊", "呃", "额", "哦", "噢", "哈", "呵", "嘿", "那个", "就是", "然后"},
+	"en": {"um", "uh", "erm", "er", "ah", "oh", "hmm", "mm", "ha", "he", "lol"},
+	"ko": {"음", "어", "아", "그", "하", "ㅋ", "ㅎ"},
+}
+
+// noiseFilter decides which STT finals are translated. It keeps the last
+// accepted final for dedupe, so one filter serves one streamer.
+type noiseFilter struct {
+	cfg     config.FilterConfig
+	lang    string              // streamer source language, used when STT reports none
+	fillers map[string][]string // base language → fillers, longest first
+
+	lastText string
+	lastAt   time.Time
+}
+
+func newNoiseFilter(cfg config.FilterConfig, sourceLang string) *noiseFilter {
+	f := &noiseFilter{cfg: cfg, lang: sourceLang, fillers: make(map[string][]string)}
+	add := func(lang string, words []string) {
+		base := baseLang(lang)
+		for _, w := range words {
+			if w = normalizeUtterance(w); w != "" {
+				f.fillers[base] = append(f.fillers[base], w)
+			}
+		}
+	}
+	if cfg.DefaultFillers {
+		for lang, words := range defaultFillers {
+			add(lang, words)
+		}
+	}
+	for lang, words := range cfg.Fillers {
+		add(lang, words)
+	}
+	for lang := range f.fillers {
+		words := f.fillers[lang]
+		sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	}
+	return f
+}
+
+// check returns why r should be dropped, or "" to translate it.
+func (f *noiseFilter) check(r stt.StreamResult, now time.Time) string {
+	norm := normalizeUtterance(r.Text)
+
+	if f.cfg.MinConfidence > 0 && r.Confidence > 0 && r.Confidence < f.cfg.MinConfidence {
+		return FilterLowConfidence
+	}
+	if f.cfg.MinLength > 0 && utf8.RuneCountInString(norm) < f.cfg.MinLength {
+		return FilterTooShort
+	}
+	lang := r.Language
+	if lang == "" {
+		lang = f.lang
+	}
+	if isFiller(norm, f.fillers[baseLang(lang)]) {
+		return FilterFiller
+	}
+	if f.cfg.Dedupe {
+		dup := norm == f.lastText && now.Sub(f.lastAt) < dedupeWindow
+		f.lastText, f.lastAt = norm, now
+		if dup {
+			return FilterDuplicate
+		}
+	}
+	return ""
+}
+
+// isFiller reports whether text consists only of filler words.
+// words must be normalized and sorted longest first.
+func isFiller(text string, words []string) bool {
+	if text == "" || len(words) == 0 {
+		return false
+	}
+	// ok[i]: text[i:] splits into fillers. A greedy match is not enough:
+	// "はははは" is "はは"+"はは", not "ははは"+"は".
+	ok := make([]bool, len(text)+1)
+	ok[len(text)] = true
+	for i := len(text) - 1; i >= 0; i-- {
+		for _, w := range words {
+			if strings.HasPrefix(text[i:], w) && ok[i+len(w)] {
+				ok[i] = true
+				break
+			}
+		}
+	}
+	return ok[0]
+}
+
+// normalizeUtterance lowercases text and drops punctuation, symbols and
+// spaces, so "えー、" and "えー…" compare equal.
+func normalizeUtterance(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// baseLang returns the lowercase language part of a code ("ja-JP" → "ja").
+func baseLang(lang string) string {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
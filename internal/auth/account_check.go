@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// biliCheckInterval is how often stored accounts are checked for a
+// working login.
+const biliCheckInterval = 30 * time.Minute
+
+// BiliValidityChange is an account whose login state changed in a check.
+type BiliValidityChange struct {
+	Name   string `json:"name"`
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// RunBiliValidityCheck checks every stored account until ctx is done.
+// onChange is called with the accounts whose valid flag changed.
+func (s *Store) RunBiliValidityCheck(ctx context.Context, onChange func([]BiliValidityChange)) {
+	ticker := time.NewTicker(biliCheckInterval)
+	defer ticker.Stop()
+	for {
+		if changes := s.CheckBiliAccounts(); len(changes) > 0 && onChange != nil {
+			onChange(changes)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckBiliAccounts checks the login of every stored account and updates
+// its valid flag. Accounts whose check fails (network error, unexpected
+// response) keep their flag. Changes are written to the audit log.
+func (s *Store) CheckBiliAccounts() []BiliValidityChange {
+	accounts, err := s.ListBiliAccounts()
+	if err != nil {
+		slog.Error("account check: list accounts", "err", err)
+		return nil
+	}
+	var changes []BiliValidityChange
+	for _, a := range accounts {
+		valid, reason, err := CheckBiliLogin(a.SESSDATA)
+		if err != nil {
+			slog.Warn("account check failed", "account", a.Name, "err", err)
+			continue
+		}
+		if valid == a.Valid {
+			continue
+		}
+		if err := s.SetBiliAccountValid(a.ID, valid); err != nil {
+			slog.Error("account check: save failed", "account", a.Name, "err", err)
+			continue
+		}
+		if valid {
+			slog.Info("bilibili account valid again", "account", a.Name)
+			s.Log(0, "system", "bili_account_valid", a.Name, "")
+		} else {
+			slog.Warn("bilibili account invalid", "account", a.Name, "reason", reason)
+			s.Log(0, "system", "bili_account_invalid", fmt.Sprintf("%s: %s", a.Name, reason), "")
+		}
+		changes = append(changes, BiliValidityChange{Name: a.Name, Valid: valid, Reason: reason})
+	}
+	return changes
+}
+
+// SetBiliAccountValid updates an account's valid flag.
+func (s *Store) SetBiliAccountValid(id int64, valid bool) error {
+	_, err := s.db.Exec(`UPDATE bili_accounts SET valid=? WHERE id=?`, valid, id)
+	return err
+}
+
+// CheckBiliLogin asks the nav endpoint whether sessdata is logged in.
+// reason explains a false result. An error means the check itself failed
+// and says nothing about the account.
+func CheckBiliLogin(sessdata string) (valid bool, reason string, err error) {
+	req, err := http.NewRequest("GET", "https://api.bilibili.com/x/web-interface/nav", nil)
+	if err != nil {
+		return false, "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Cookie", "SESSDATA="+sessdata)
+	req.Header.Set("User-Agent", "Mozilla/5.0 livesub/1.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, "", fmt.Errorf("read body: %w", err)
+	}
+	var result struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			IsLogin bool `json:"isLogin"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, "", fmt.Errorf("parse json: %w", err)
+	}
+	switch {
+	case result.Code == -101:
+		return false, "not logged in (code -101)", nil
+	case result.Code != 0:
+		return false, "", fmt.Errorf("nav: code=%d %s", result.Code, result.Message)
+	case !result.Data.IsLogin:
+		return false, "not logged in", nil
+	}
+	return true, "", nil
+}
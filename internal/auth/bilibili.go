@@ -20,6 +20,8 @@ type BiliAccount struct {
 	CreatedAt  string `json:"created_at"`
 	ExpiresAt  string `json:"expires_at,omitempty"`
 	Valid      bool   `json:"valid"` // whether cookies are still working
+
+	RefreshToken string `json:"-"` // for the cookie refresh flow, from QR login
 }
 
 // BiliAccountSummary is the safe version without credentials.
@@ -44,18 +46,30 @@ func (s *Store) migrateBili() error {
 			danmaku_max INTEGER NOT NULL DEFAULT 20,
 			created_at DATETIME NOT NULL DEFAULT (datetime('now')),
 			expires_at TEXT,
-			valid INTEGER NOT NULL DEFAULT 1
+			valid INTEGER NOT NULL DEFAULT 1,
+			refresh_token TEXT NOT NULL DEFAULT ''
 		);
 	`)
+	if err != nil {
+		return err
+	}
+	// Databases created before cookie refresh lack refresh_token
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('bili_accounts') WHERE name='refresh_token'`).Scan(&n); err != nil {
+		return err
+	}
+	if n == 0 {
+		_, err = s.db.Exec(`ALTER TABLE bili_accounts ADD COLUMN refresh_token TEXT NOT NULL DEFAULT ''`)
+	}
 	return err
 }
 
 // SaveBiliAccount inserts or updates a Bilibili account.
-func (s *Store) SaveBiliAccount(name, sessdata, biliJCT string, uid int64, danmakuMax int, expiresAt string) (*BiliAccount, error) {
+func (s *Store) SaveBiliAccount(name, sessdata, biliJCT string, uid int64, danmakuMax int, expiresAt, refreshToken string) (*BiliAccount, error) {
 	// Update if same name exists
 	res, err := s.db.Exec(
-		`UPDATE bili_accounts SET sessdata=?, bili_jct=?, uid=?, danmaku_max=?, expires_at=?, valid=1 WHERE name=?`,
-		sessdata, biliJCT, uid, danmakuMax, expiresAt, name,
+		`UPDATE bili_accounts SET sessdata=?, bili_jct=?, uid=?, danmaku_max=?, expires_at=?, refresh_token=?, valid=1 WHERE name=?`,
+		sessdata, biliJCT, uid, danmakuMax, expiresAt, refreshToken, name,
 	)
 	if err != nil {
 		return nil, err
@@ -68,22 +82,22 @@ func (s *Store) SaveBiliAccount(name, sessdata, biliJCT string, uid int64, danma
 
 	// Insert new
 	r, err := s.db.Exec(
-		`INSERT INTO bili_accounts (name, sessdata, bili_jct, uid, danmaku_max, expires_at) VALUES (?, ?, ?, ?, ?, ?)`,
-		name, sessdata, biliJCT, uid, danmakuMax, expiresAt,
+		`INSERT INTO bili_accounts (name, sessdata, bili_jct, uid, danmaku_max, expires_at, refresh_token) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		name, sessdata, biliJCT, uid, danmakuMax, expiresAt, refreshToken,
 	)
 	if err != nil {
 		return nil, err
 	}
 	id, _ := r.LastInsertId()
-	return &BiliAccount{ID: id, Name: name, SESSDATA: sessdata, BiliJCT: biliJCT, UID: uid, DanmakuMax: danmakuMax, Valid: true}, nil
+	return &BiliAccount{ID: id, Name: name, SESSDATA: sessdata, BiliJCT: biliJCT, UID: uid, DanmakuMax: danmakuMax, ExpiresAt: expiresAt, Valid: true, RefreshToken: refreshToken}, nil
 }
 
 func (s *Store) getBiliAccountByName(name string) (*BiliAccount, error) {
 	var a BiliAccount
 	var expiresAt sql.NullString
 	err := s.db.QueryRow(
-		`SELECT id, name, sessdata, bili_jct, uid, danmaku_max, created_at, expires_at, valid FROM bili_accounts WHERE name=?`, name,
-	).Scan(&a.ID, &a.Name, &a.SESSDATA, &a.BiliJCT, &a.UID, &a.DanmakuMax, &a.CreatedAt, &expiresAt, &a.Valid)
+		`SELECT id, name, sessdata, bili_jct, uid, danmaku_max, created_at, expires_at, valid, refresh_token FROM bili_accounts WHERE name=?`, name,
+	).Scan(&a.ID, &a.Name, &a.SESSDATA, &a.BiliJCT, &a.UID, &a.DanmakuMax, &a.CreatedAt, &expiresAt, &a.Valid, &a.RefreshToken)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +109,7 @@ func (s *Store) getBiliAccountByName(name string) (*BiliAccount, error) {
 
 // ListBiliAccounts returns all accounts (with credentials).
 func (s *Store) ListBiliAccounts() ([]BiliAccount, error) {
-	rows, err := s.db.Query(`SELECT id, name, sessdata, bili_jct, uid, danmaku_max, created_at, COALESCE(expires_at,''), valid FROM bili_accounts ORDER BY id`)
+	rows, err := s.db.Query(`SELECT id, name, sessdata, bili_jct, uid, danmaku_max, created_at, COALESCE(expires_at,''), valid, refresh_token FROM bili_accounts ORDER BY id`)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +118,7 @@ func (s *Store) ListBiliAccounts() ([]BiliAccount, error) {
 	var accounts []BiliAccount
 	for rows.Next() {
 		var a BiliAccount
-		if err := rows.Scan(&a.ID, &a.Name, &a.SESSDATA, &a.BiliJCT, &a.UID, &a.DanmakuMax, &a.CreatedAt, &a.ExpiresAt, &a.Valid); err != nil {
+		if err := rows.Scan(&a.ID, &a.Name, &a.SESSDATA, &a.BiliJCT, &a.UID, &a.DanmakuMax, &a.CreatedAt, &a.ExpiresAt, &a.Valid, &a.RefreshToken); err != nil {
 			return nil, err
 		}
 		accounts = append(accounts, a)
@@ -143,6 +157,15 @@ func (s *Store) UpdateBiliAccountDanmakuMax(id int64, max int) error {
 	return err
 }
 
+// UpdateBiliCookies stores the cookies of a refreshed login.
+func (s *Store) UpdateBiliCookies(id int64, sessdata, biliJCT, refreshToken, expiresAt string) error {
+	_, err := s.db.Exec(
+		`UPDATE bili_accounts SET sessdata=?, bili_jct=?, refresh_token=?, expires_at=?, valid=1 WHERE id=?`,
+		sessdata, biliJCT, refreshToken, expiresAt, id,
+	)
+	return err
+}
+
 // --- Bilibili QR Login ---
 
 type QRCodeResult struct {
@@ -185,6 +208,9 @@ type QRPollResult struct {
 	SESSDATA string `json:"sessdata,omitempty"`
 	BiliJCT  string `json:"bili_jct,omitempty"`
 	UID      int64  `json:"uid,omitempty"`
+
+	RefreshToken string `json:"-"`
+	ExpiresAt    string `json:"-"` // SESSDATA expiry, RFC 3339
 }
 
 // PollQRCode checks login status and extracts cookies on success.
@@ -214,7 +240,8 @@ func PollQRCode(qrcodeKey string) (*QRPollResult, error) {
 			Code      int    `json:"code"`
 			Message   string `json:"message"`
 			URL       string `json:"url"`
-			Timestamp int64  `json:"timestamp"`
+			Timestamp    int64  `json:"timestamp"`
+			RefreshToken string `json:"refresh_token"`
 		} `json:"data"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -230,12 +257,15 @@ func PollQRCode(qrcodeKey string) (*QRPollResult, error) {
 		return &QRPollResult{Status: "expired"}, nil
 	case 0:
 		// Success! Extract cookies
-		var sessdata, biliJCT string
+		var sessdata, biliJCT, expiresAt string
 		var uid int64
 		for _, cookie := range resp.Cookies() {
 			switch cookie.Name {
 			case "SESSDATA":
 				sessdata = cookie.Value
+				if !cookie.Expires.IsZero() {
+					expiresAt = cookie.Expires.Format(time.RFC3339)
+				}
 			case "bili_jct":
 				biliJCT = cookie.Value
 			case "DedeUserID":
@@ -250,6 +280,9 @@ func PollQRCode(qrcodeKey string) (*QRPollResult, error) {
 			SESSDATA: sessdata,
 			BiliJCT:  biliJCT,
 			UID:      uid,
+
+			RefreshToken: result.Data.RefreshToken,
+			ExpiresAt:    expiresAt,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unknown status code %d: %s", result.Data.Code, result.Data.Message)
@@ -0,0 +1,118 @@
+package auth
+
+// CachedTranslation is a persisted translation cache entry.
+type CachedTranslation struct {
+	ID         int64  `json:"id"`
+	Text       string `json:"text"` // normalized source text
+	SourceLang string `json:"source_lang"`
+	TargetLang string `json:"target_lang"`
+	Version    string `json:"version"` // prompt version / backend
+	Translated string `json:"translated"`
+	Hits       int64  `json:"hits"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+func (s *Store) migrateCache() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS translation_cache (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			text TEXT NOT NULL,
+			source_lang TEXT NOT NULL,
+			target_lang TEXT NOT NULL,
+			version TEXT NOT NULL,
+			translated TEXT NOT NULL,
+			hits INTEGER NOT NULL DEFAULT 0,
+			updated_at DATETIME NOT NULL DEFAULT (datetime('now')),
+			UNIQUE (text, source_lang, target_lang, version)
+		);
+	`)
+	return err
+}
+
+// GetCachedTranslation looks up a cached translation and counts the hit.
+func (s *Store) GetCachedTranslation(text, sourceLang, targetLang, version string) (string, bool) {
+	var id int64
+	var translated string
+	err := s.db.QueryRow(
+		`SELECT id, translated FROM translation_cache WHERE text=? AND source_lang=? AND target_lang=? AND version=?`,
+		text, sourceLang, targetLang, version,
+	).Scan(&id, &translated)
+	if err != nil {
+		return "", false
+	}
+	s.db.Exec(`UPDATE translation_cache SET hits = hits + 1 WHERE id = ?`, id)
+	return translated, true
+}
+
+// PutCachedTranslation inserts or replaces a cached translation.
+func (s *Store) PutCachedTranslation(text, sourceLang, targetLang, version, translated string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO translation_cache (text, source_lang, target_lang, version, translated)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (text, source_lang, target_lang, version)
+		DO UPDATE SET translated = excluded.translated, updated_at = datetime('now')`,
+		text, sourceLang, targetLang, version, translated,
+	)
+	return err
+}
+
+// ListCachedTranslations returns cache entries whose source or translation
+// contains query (all if empty), most used first.
+func (s *Store) ListCachedTranslations(query string, limit int) ([]CachedTranslation, error) {
+	like := "%" + query + "%"
+	rows, err := s.db.Query(`
+		SELECT id, text, source_lang, target_lang, version, translated, hits, updated_at
+		FROM translation_cache
+		WHERE ? = '' OR text LIKE ? OR translated LIKE ?
+		ORDER BY hits DESC, id DESC LIMIT ?`,
+		query, like, like, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []CachedTranslation
+	for rows.Next() {
+		var e CachedTranslation
+		if err := rows.Scan(&e.ID, &e.Text, &e.SourceLang, &e.TargetLang, &e.Version, &e.Translated, &e.Hits, &e.UpdatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// GetCachedTranslationByID returns one cache entry.
+func (s *Store) GetCachedTranslationByID(id int64) (*CachedTranslation, error) {
+	var e CachedTranslation
+	err := s.db.QueryRow(`
+		SELECT id, text, source_lang, target_lang, version, translated, hits, updated_at
+		FROM translation_cache WHERE id = ?`, id,
+	).Scan(&e.ID, &e.Text, &e.SourceLang, &e.TargetLang, &e.Version, &e.Translated, &e.Hits, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// UpdateCachedTranslation replaces the translation of a cache entry.
+func (s *Store) UpdateCachedTranslation(id int64, translated string) error {
+	_, err := s.db.Exec(`UPDATE translation_cache SET translated = ?, updated_at = datetime('now') WHERE id = ?`, translated, id)
+	return err
+}
+
+// DeleteCachedTranslation removes one cache entry.
+func (s *Store) DeleteCachedTranslation(id int64) error {
+	_, err := s.db.Exec(`DELETE FROM translation_cache WHERE id = ?`, id)
+	return err
+}
+
+// PurgeTranslationCache removes all cache entries and returns how many were removed.
+func (s *Store) PurgeTranslationCache() (int64, error) {
+	res, err := s.db.Exec(`DELETE FROM translation_cache`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Cookie refresh schedule: accounts are checked every biliRefreshInterval
+// and refreshed when Bilibili asks for it or the cookie expires within
+// biliRefreshBefore.
+const (
+	biliRefreshInterval = 6 * time.Hour
+	biliRefreshBefore   = 7 * 24 * time.Hour
+)
+
+// biliRefreshPubKey encrypts the correspond path of the refresh flow.
+const biliRefreshPubKey = `-----BEGIN PUBLIC KEY-----
+MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQDLgd2OAkcGVtoE3ThUREbio0Eg
+Uc/prcajMKXvkCKFCWhJYJcLkcM2DKKcSeFpD/j6Boy538YXnR6VhcuUJOhH2x71
+nzPjfdTcqMz7djHum0qSZA0AyCBDABUqCrfNgCiJ00Ra7GmRj+YCK1NJEuewlb40
+JNrRuoEUXpabUzGB8QIDAQAB
+-----END PUBLIC KEY-----`
+
+var refreshCSRFRe = regexp.MustCompile(`<div id="1-name">([^<]+)</div>`)
+
+// BiliCookies is the login state returned by a cookie refresh.
+type BiliCookies struct {
+	SESSDATA     string
+	BiliJCT      string
+	RefreshToken string
+	ExpiresAt    time.Time // zero if Bilibili did not say
+}
+
+// RunBiliCookieRefresh refreshes the cookies of stored accounts until ctx
+// is done. onRefresh is called after any account got new cookies, so the
+// caller can push them into the running bots.
+func (s *Store) RunBiliCookieRefresh(ctx context.Context, onRefresh func()) {
+	ticker := time.NewTicker(biliRefreshInterval)
+	defer ticker.Stop()
+	for {
+		if s.refreshBiliAccounts() > 0 && onRefresh != nil {
+			onRefresh()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshBiliAccounts refreshes every account that is due and returns how
+// many were updated. Each attempt is written to the audit log.
+func (s *Store) refreshBiliAccounts() int {
+	accounts, err := s.ListBiliAccounts()
+	if err != nil {
+		slog.Error("cookie refresh: list accounts", "err", err)
+		return 0
+	}
+	updated := 0
+	for _, a := range accounts {
+		if !a.Valid || a.RefreshToken == "" {
+			continue
+		}
+		due, err := biliRefreshDue(a)
+		if err != nil {
+			slog.Warn("cookie refresh: check failed", "account", a.Name, "err", err)
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		c, err := RefreshBiliCookies(a.SESSDATA, a.BiliJCT, a.RefreshToken)
+		if err != nil && c != nil {
+			// New cookies were issued; keep them even though the confirm failed
+			slog.Warn("cookie refresh: confirm failed", "account", a.Name, "err", err)
+			s.Log(0, "system", "refresh_bili_cookie", fmt.Sprintf("%s: refreshed, confirm failed: %v", a.Name, err), "")
+		} else if err != nil {
+			slog.Error("cookie refresh failed", "account", a.Name, "err", err)
+			s.Log(0, "system", "refresh_bili_cookie", fmt.Sprintf("%s: failed: %v", a.Name, err), "")
+			continue
+		}
+		expiresAt := a.ExpiresAt // keep the known expiry if Bilibili did not send one
+		if !c.ExpiresAt.IsZero() {
+			expiresAt = c.ExpiresAt.Format(time.RFC3339)
+		}
+		if err := s.UpdateBiliCookies(a.ID, c.SESSDATA, c.BiliJCT, c.RefreshToken, expiresAt); err != nil {
+			// The old refresh_token is already spent; nothing to retry with
+			slog.Error("cookie refresh: save failed", "account", a.Name, "err", err)
+			s.Log(0, "system", "refresh_bili_cookie", fmt.Sprintf("%s: refreshed but not saved: %v", a.Name, err), "")
+			continue
+		}
+		slog.Info("bilibili cookie refreshed", "account", a.Name, "expires_at", expiresAt)
+		s.Log(0, "system", "refresh_bili_cookie", fmt.Sprintf("%s: ok, expires %s", a.Name, expiresAt), "")
+		updated++
+	}
+	return updated
+}
+
+// biliRefreshDue reports whether a's cookie should be refreshed now.
+func biliRefreshDue(a BiliAccount) (bool, error) {
+	if a.ExpiresAt != "" {
+		if exp, err := time.Parse(time.RFC3339, a.ExpiresAt); err == nil && time.Until(exp) < biliRefreshBefore {
+			return true, nil
+		}
+	}
+	return CheckBiliCookie(a.SESSDATA, a.BiliJCT)
+}
+
+// CheckBiliCookie asks Bilibili whether the cookie needs a refresh.
+func CheckBiliCookie(sessdata, biliJCT string) (bool, error) {
+	body, _, err := biliDo("GET", "https://passport.bilibili.com/x/passport-login/web/cookie/info?csrf="+url.QueryEscape(biliJCT), nil, sessdata, biliJCT)
+	if err != nil {
+		return false, err
+	}
+	var result struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Refresh bool `json:"refresh"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, fmt.Errorf("parse json: %w", err)
+	}
+	if result.Code != 0 {
+		return false, fmt.Errorf("cookie info: code=%d %s", result.Code, result.Message)
+	}
+	return result.Data.Refresh, nil
+}
+
+// RefreshBiliCookies runs Bilibili's web cookie refresh flow and confirms
+// it, which invalidates the old cookies and refresh_token. If only the
+// confirm fails, the new cookies are returned with the error: the old
+// refresh_token is spent by then, so they must be saved anyway.
+func RefreshBiliCookies(sessdata, biliJCT, refreshToken string) (*BiliCookies, error) {
+	path, err := correspondPath(time.Now().UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	page, _, err := biliDo("GET", "https://www.bilibili.com/correspond/1/"+path, nil, sessdata, biliJCT)
+	if err != nil {
+		return nil, fmt.Errorf("correspond: %w", err)
+	}
+	m := refreshCSRFRe.FindSubmatch(page)
+	if m == nil {
+		return nil, fmt.Errorf("correspond: refresh_csrf not found")
+	}
+
+	body, resp, err := biliDo("POST", "https://passport.bilibili.com/x/passport-login/web/cookie/refresh", url.Values{
+		"csrf":          {biliJCT},
+		"refresh_csrf":  {string(m[1])},
+		"source":        {"main_web"},
+		"refresh_token": {refreshToken},
+	}, sessdata, biliJCT)
+	if err != nil {
+		return nil, fmt.Errorf("refresh: %w", err)
+	}
+	var result struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			RefreshToken string `json:"refresh_token"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("refresh: parse json: %w", err)
+	}
+	if result.Code != 0 {
+		return nil, fmt.Errorf("refresh: code=%d %s", result.Code, result.Message)
+	}
+
+	c := &BiliCookies{RefreshToken: result.Data.RefreshToken}
+	for _, cookie := range resp.Cookies() {
+		switch cookie.Name {
+		case "SESSDATA":
+			c.SESSDATA = cookie.Value
+			c.ExpiresAt = cookie.Expires
+		case "bili_jct":
+			c.BiliJCT = cookie.Value
+		}
+	}
+	if c.SESSDATA == "" || c.BiliJCT == "" || c.RefreshToken == "" {
+		return nil, fmt.Errorf("refresh: new cookies not found in response")
+	}
+
+	// Confirm with the new cookies and the old refresh_token
+	body, _, err = biliDo("POST", "https://passport.bilibili.com/x/passport-login/web/confirm/refresh", url.Values{
+		"csrf":          {c.BiliJCT},
+		"refresh_token": {refreshToken},
+	}, c.SESSDATA, c.BiliJCT)
+	if err != nil {
+		return c, fmt.Errorf("confirm: %w", err)
+	}
+	var confirm struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &confirm); err != nil {
+		return c, fmt.Errorf("confirm: parse json: %w", err)
+	}
+	if confirm.Code != 0 {
+		return c, fmt.Errorf("confirm: code=%d %s", confirm.Code, confirm.Message)
+	}
+	return c, nil
+}
+
+// correspondPath encrypts "refresh_<ts>" with Bilibili's public key.
+func correspondPath(ts int64) (string, error) {
+	block, _ := pem.Decode([]byte(biliRefreshPubKey))
+	if block == nil {
+		return "", fmt.Errorf("decode refresh public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("parse refresh public key: %w", err)
+	}
+	out, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, key.(*rsa.PublicKey), []byte(fmt.Sprintf("refresh_%d", ts)), nil)
+	if err != nil {
+		return "", fmt.Errorf("encrypt correspond path: %w", err)
+	}
+	return hex.EncodeToString(out), nil
+}
+
+// biliDo sends a request with the account's cookies. form, if set, is
+// sent as a urlencoded body.
+func biliDo(method, rawURL string, form url.Values, sessdata, biliJCT string) ([]byte, *http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request: %w", err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("Cookie", "SESSDATA="+sessdata+"; bili_jct="+biliJCT)
+	req.Header.Set("User-Agent", "Mozilla/5.0 livesub/1.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("http %d", resp.StatusCode)
+	}
+	return data, resp, nil
+}
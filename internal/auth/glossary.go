@@ -0,0 +1,89 @@
+package auth
+
+import "strings"
+
+// GlossaryEntry is a fixed translation for one term of a streamer,
+// keyed by room ID like streams.
+type GlossaryEntry struct {
+	ID         int64    `json:"id"`
+	RoomID     int64    `json:"room_id"`
+	Source     string   `json:"source"`                // term as it appears in the source text
+	Target     string   `json:"target"`                // required rendering in the translation
+	TargetLang string   `json:"target_lang,omitempty"` // "" = all target languages
+	Variants   []string `json:"variants,omitempty"`    // known wrong renderings to replace with Target
+	Note       string   `json:"note,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+func (s *Store) migrateGlossary() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS glossary (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			room_id INTEGER NOT NULL,
+			source TEXT NOT NULL,
+			target TEXT NOT NULL,
+			target_lang TEXT NOT NULL DEFAULT '',
+			variants TEXT NOT NULL DEFAULT '',
+			note TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+			UNIQUE (room_id, source, target_lang)
+		);
+		CREATE INDEX IF NOT EXISTS idx_glossary_room ON glossary(room_id);
+	`)
+	return err
+}
+
+// SaveGlossaryEntry inserts e, or updates it if e.ID is set.
+func (s *Store) SaveGlossaryEntry(e GlossaryEntry) (*GlossaryEntry, error) {
+	variants := strings.Join(e.Variants, "\n")
+	if e.ID > 0 {
+		_, err := s.db.Exec(
+			`UPDATE glossary SET room_id=?, source=?, target=?, target_lang=?, variants=?, note=? WHERE id=?`,
+			e.RoomID, e.Source, e.Target, e.TargetLang, variants, e.Note, e.ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &e, nil
+	}
+	res, err := s.db.Exec(
+		`INSERT INTO glossary (room_id, source, target, target_lang, variants, note) VALUES (?, ?, ?, ?, ?, ?)`,
+		e.RoomID, e.Source, e.Target, e.TargetLang, variants, e.Note,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.ID, _ = res.LastInsertId()
+	return &e, nil
+}
+
+// DeleteGlossaryEntry removes a glossary entry.
+func (s *Store) DeleteGlossaryEntry(id int64) error {
+	_, err := s.db.Exec(`DELETE FROM glossary WHERE id = ?`, id)
+	return err
+}
+
+// ListGlossary returns the glossary entries of a room, ordered by source term.
+func (s *Store) ListGlossary(roomID int64) ([]GlossaryEntry, error) {
+	rows, err := s.db.Query(
+		`SELECT id, room_id, source, target, target_lang, variants, note, created_at
+		 FROM glossary WHERE room_id = ? ORDER BY source, target_lang`, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []GlossaryEntry
+	for rows.Next() {
+		var e GlossaryEntry
+		var variants string
+		if err := rows.Scan(&e.ID, &e.RoomID, &e.Source, &e.Target, &e.TargetLang, &variants, &e.Note, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if variants != "" {
+			e.Variants = strings.Split(variants, "\n")
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -39,6 +39,15 @@ func NewStore(dbPath string) (*Store, error) {
 	if err := s.migrateStreams(); err != nil {
 		return nil, fmt.Errorf("migrate streams: %w", err)
 	}
+	if err := s.migrateGlossary(); err != nil {
+		return nil, fmt.Errorf("migrate glossary: %w", err)
+	}
+	if err := s.migrateCache(); err != nil {
+		return nil, fmt.Errorf("migrate cache: %w", err)
+	}
+	if err := s.migrateTwitch(); err != nil {
+		return nil, fmt.Errorf("migrate twitch: %w", err)
+	}
 	return s, nil
 }
 
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"strings"
+)
+
+// TwitchAccount is a stored Twitch chat account.
+type TwitchAccount struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`  // bot pool name, shared namespace with Bilibili accounts
+	Login     string `json:"login"` // Twitch username (IRC NICK)
+	Token     string `json:"token,omitempty"`
+	CreatedAt string `json:"created_at"`
+	Valid     bool   `json:"valid"`
+}
+
+// TwitchAccountSummary is the safe version without the token.
+type TwitchAccountSummary struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Login     string `json:"login"`
+	CreatedAt string `json:"created_at"`
+	Valid     bool   `json:"valid"`
+}
+
+func (s *Store) migrateTwitch() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS twitch_accounts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT UNIQUE NOT NULL,
+			login TEXT NOT NULL,
+			token TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+			valid INTEGER NOT NULL DEFAULT 1
+		);
+	`)
+	return err
+}
+
+// SaveTwitchAccount inserts or updates (by name) a Twitch account.
+// token is the chat OAuth token, with or without the "oauth:" prefix.
+func (s *Store) SaveTwitchAccount(name, login, token string) error {
+	token = strings.TrimPrefix(token, "oauth:")
+	_, err := s.db.Exec(
+		`INSERT INTO twitch_accounts (name, login, token) VALUES (?, ?, ?)
+		 ON CONFLICT(name) DO UPDATE SET login=excluded.login, token=excluded.token, valid=1`,
+		name, strings.ToLower(login), token,
+	)
+	return err
+}
+
+// ListTwitchAccounts returns all accounts (with tokens).
+func (s *Store) ListTwitchAccounts() ([]TwitchAccount, error) {
+	rows, err := s.db.Query(`SELECT id, name, login, token, created_at, valid FROM twitch_accounts ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []TwitchAccount
+	for rows.Next() {
+		var a TwitchAccount
+		if err := rows.Scan(&a.ID, &a.Name, &a.Login, &a.Token, &a.CreatedAt, &a.Valid); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, nil
+}
+
+// ListTwitchAccountSummaries returns accounts without tokens.
+func (s *Store) ListTwitchAccountSummaries() ([]TwitchAccountSummary, error) {
+	accounts, err := s.ListTwitchAccounts()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TwitchAccountSummary, len(accounts))
+	for i, a := range accounts {
+		out[i] = TwitchAccountSummary{ID: a.ID, Name: a.Name, Login: a.Login, CreatedAt: a.CreatedAt, Valid: a.Valid}
+	}
+	return out, nil
+}
+
+// DeleteTwitchAccount removes an account and returns its name.
+func (s *Store) DeleteTwitchAccount(id int64) (string, error) {
+	var name string
+	if err := s.db.QueryRow(`SELECT name FROM twitch_accounts WHERE id=?`, id).Scan(&name); err != nil {
+		return "", err
+	}
+	_, err := s.db.Exec(`DELETE FROM twitch_accounts WHERE id=?`, id)
+	return name, err
+}
@@ -60,7 +60,8 @@ func (b *BilibiliBot) SetRoomID(roomID int64) {
 }
 
 // Send sends a danmaku message to the specified room. Long messages are split into chunks.
-// If roomID is 0, falls back to the bot's default roomID.
+// If roomID is 0, falls back to the bot's default roomID. Recognised Bilibili
+// failures wrap one of the Err* sentinels.
 func (b *BilibiliBot) Send(ctx context.Context, roomID int64, msg string) error {
 	b.mu.Lock()
 	sender := b.sender
@@ -69,17 +70,19 @@ func (b *BilibiliBot) Send(ctx context.Context, roomID int64, msg string) error
 	}
 	b.mu.Unlock()
 
-	err := sender.Send(ctx, roomID, msg)
+	err := classifyBilibili(sender.Send(ctx, roomID, msg))
 	if err != nil {
-		slog.Warn("danmaku send failed", "bot", b.name, "room", roomID, "error", err)
+		slog.Warn("danmaku send failed", "bot", b.name, "room", roomID, "reason", Reason(err), "error", err)
 	}
 	return err
 }
 
 // UpdateCredentials replaces the bot's credentials and rebuilds the sender.
-func (b *BilibiliBot) UpdateCredentials(sessdata, biliJCT string, uid int64, danmakuMax int) {
+// It reports whether the cookies changed.
+func (b *BilibiliBot) UpdateCredentials(sessdata, biliJCT string, uid int64, danmakuMax int) bool {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	changed := sessdata != b.sessdata || biliJCT != b.biliJCT
 	b.sessdata = sessdata
 	b.biliJCT = biliJCT
 	b.uid = uid
@@ -91,4 +94,5 @@ func (b *BilibiliBot) UpdateCredentials(sessdata, biliJCT string, uid int64, dan
 		dm.WithMaxLength(b.danmakuMax),
 		dm.WithCooldown(2*time.Second),
 	)
+	return changed
 }
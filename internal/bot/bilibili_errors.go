@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Bilibili danmaku send failures. Errors returned by BilibiliBot.Send wrap
+// one of these when the response could be recognised, so callers can test
+// them with errors.Is and Classify maps them to an ErrorKind.
+var (
+	ErrNotLoggedIn error = &biliError{"not_logged_in", KindAuthExpired, "bilibili: account not logged in"}
+	ErrTooFrequent error = &biliError{"too_frequent", KindRateLimited, "bilibili: sending too frequently"}
+	ErrSensitive   error = &biliError{"sensitive", KindContentRejected, "bilibili: message blocked as sensitive"}
+	ErrRoomMuted   error = &biliError{"room_muted", KindMuted, "bilibili: account muted or banned in room"}
+	ErrMsgTooLong  error = &biliError{"msg_too_long", KindContentRejected, "bilibili: message too long"}
+)
+
+// biliError is a Bilibili sentinel. It carries its ErrorKind and a short
+// reason key for the UI and logs.
+type biliError struct {
+	reason string
+	kind   ErrorKind
+	text   string
+}
+
+func (e *biliError) Error() string   { return e.text }
+func (e *biliError) Kind() ErrorKind { return e.kind }
+func (e *biliError) Reason() string  { return e.reason }
+
+// biliCodes maps msg/send response codes to sentinels.
+var biliCodes = map[int]error{
+	-101:    ErrNotLoggedIn, // 账号未登录
+	-111:    ErrNotLoggedIn, // csrf 校验失败 (bili_jct no longer matches SESSDATA)
+	10030:   ErrTooFrequent, // 您发送弹幕的频率过快
+	10031:   ErrTooFrequent, // 您发送弹幕的频率过快 (repeated message)
+	1003:    ErrRoomMuted,   // 您已被禁言
+	11000:   ErrSensitive,   // 弹幕被吞了
+	1003212: ErrMsgTooLong,  // 超出限制长度
+}
+
+// biliMessages maps fragments of the response message to sentinels, for
+// errors that arrive without a code. Checked in order.
+var biliMessages = []struct {
+	fragment string
+	err      error
+}{
+	{"未登录", ErrNotLoggedIn},
+	{"not logged in", ErrNotLoggedIn},
+	{"csrf", ErrNotLoggedIn},
+	{"频率过快", ErrTooFrequent},
+	{"过于频繁", ErrTooFrequent},
+	{"too frequent", ErrTooFrequent},
+	{"禁言", ErrRoomMuted},
+	{"黑名单", ErrRoomMuted},
+	{"muted", ErrRoomMuted},
+	{"banned", ErrRoomMuted},
+	{"超出限制长度", ErrMsgTooLong},
+	{"too long", ErrMsgTooLong},
+	{"敏感", ErrSensitive},
+	{"屏蔽", ErrSensitive},
+	{"被吞", ErrSensitive},
+	{"sensitive", ErrSensitive},
+}
+
+var biliCodeRe = regexp.MustCompile(`(?i)code[\s"':=]*(-?\d+)`)
+
+// classifyBilibili wraps a dm sender error in a *SendError carrying the
+// response code and, if recognised, the matching sentinel. Unrecognised
+// errors are returned with only the code attached (transient).
+func classifyBilibili(err error) error {
+	if err == nil {
+		return nil
+	}
+	var se *SendError
+	if errors.As(err, &se) {
+		return err
+	}
+	if Classify(err) == KindCanceled {
+		return err
+	}
+
+	msg := err.Error()
+	code := 0
+	if m := biliCodeRe.FindStringSubmatch(msg); m != nil {
+		code, _ = strconv.Atoi(m[1])
+	}
+
+	sentinel := biliCodes[code]
+	if sentinel == nil {
+		lower := strings.ToLower(msg)
+		for _, m := range biliMessages {
+			if strings.Contains(lower, m.fragment) {
+				sentinel = m.err
+				break
+			}
+		}
+	}
+	if sentinel == nil {
+		if code == 0 {
+			return err
+		}
+		return &SendError{Kind: KindTransient, Code: code, Err: err}
+	}
+	return &SendError{
+		Kind: sentinel.(*biliError).kind,
+		Code: code,
+		Err:  fmt.Errorf("%w: %w", sentinel, err),
+	}
+}
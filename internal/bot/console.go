@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ConsoleBot writes messages as text lines instead of sending them
+// (local testing and replays).
+type ConsoleBot struct {
+	name   string
+	maxLen int
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewConsoleBot creates a bot writing to w. maxLen simulates a platform
+// message limit (0 = no limit).
+func NewConsoleBot(name string, w io.Writer, maxLen int) *ConsoleBot {
+	return &ConsoleBot{name: name, w: w, maxLen: maxLen}
+}
+
+func (b *ConsoleBot) Send(ctx context.Context, roomID int64, msg string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err := fmt.Fprintf(b.w, "%s [%s] #%d %s\n", time.Now().Format("15:04:05"), b.name, roomID, msg)
+	return err
+}
+
+func (b *ConsoleBot) Platform() string   { return "console" }
+func (b *ConsoleBot) Name() string       { return b.name }
+func (b *ConsoleBot) Available() bool    { return true }
+func (b *ConsoleBot) MaxMessageLen() int { return b.maxLen }
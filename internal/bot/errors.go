@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrorKind classifies a send failure so the caller can decide whether to
+// retry, fail over to another account or give up on the message.
+type ErrorKind int
+
+const (
+	KindTransient       ErrorKind = iota // network hiccup or server error: retry the same bot
+	KindRateLimited                      // sending too fast: wait or use another account
+	KindMuted                            // account muted or banned in the room: use another account
+	KindAuthExpired                      // cookie/token no longer valid: use another account
+	KindContentRejected                  // the message itself was refused: retrying won't help
+	KindCanceled                         // ctx cancelled: stop
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindTransient:
+		return "transient"
+	case KindRateLimited:
+		return "rate_limited"
+	case KindMuted:
+		return "muted"
+	case KindAuthExpired:
+		return "auth_expired"
+	case KindContentRejected:
+		return "content_rejected"
+	case KindCanceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// AccountSpecific reports whether another account may succeed where this
+// one failed.
+func (k ErrorKind) AccountSpecific() bool {
+	return k == KindRateLimited || k == KindMuted || k == KindAuthExpired
+}
+
+// SendError is a classified error returned by Bot.Send.
+type SendError struct {
+	Kind    ErrorKind
+	Code    int // platform response code, 0 if none
+	Err     error
+	Retried bool // the bot already retried the send itself
+}
+
+func (e *SendError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("%s (code %d): %v", e.Kind, e.Code, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+func (e *SendError) Unwrap() error { return e.Err }
+
+// Classify returns the kind of a Send error. Errors that carry no
+// classification (a *SendError or a Kind() method) are assumed transient.
+func Classify(err error) ErrorKind {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return KindCanceled
+	}
+	var se *SendError
+	if errors.As(err, &se) {
+		return se.Kind
+	}
+	var k interface{ Kind() ErrorKind }
+	if errors.As(err, &k) {
+		return k.Kind()
+	}
+	return KindTransient
+}
+
+// Retried reports whether the bot that returned err already retried, so
+// the caller should not retry again.
+func Retried(err error) bool {
+	var se *SendError
+	return errors.As(err, &se) && se.Retried
+}
+
+// Reason returns the short key of the platform error wrapped in err
+// (e.g. "too_frequent"), or "" if the cause was not recognised.
+func Reason(err error) string {
+	var r interface{ Reason() string }
+	if errors.As(err, &r) {
+		return r.Reason()
+	}
+	return ""
+}
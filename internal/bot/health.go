@@ -0,0 +1,189 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+)
+
+// Quarantine policy: a bot failing this many sends in a row with an
+// expired-login error is taken out of rotation until an admin releases it;
+// a rate-limited bot is skipped for rateLimitCooldown. Mutes are per room:
+// a bot muted in a room is skipped there only, for muteCooldown.
+const (
+	QuarantineAfter   = 3
+	rateLimitCooldown = 30 * time.Second
+	muteCooldown      = time.Hour
+)
+
+// Health is the delivery record of one bot.
+type Health struct {
+	Sends               int64  `json:"sends"`
+	Failures            int64  `json:"failures"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	LastErrorKind       string `json:"last_error_kind,omitempty"`
+	LastErrorCode       int    `json:"last_error_code,omitempty"`
+	LastReason          string `json:"last_reason,omitempty"`    // platform reason key, see Reason
+	LastSuccess         int64  `json:"last_success,omitempty"`   // unix ms
+	LastFailure         int64  `json:"last_failure,omitempty"`   // unix ms
+	CooldownUntil       int64  `json:"cooldown_until,omitempty"` // unix ms, rate limited until then
+	Quarantined         bool   `json:"quarantined"`
+	QuarantineReason    string `json:"quarantine_reason,omitempty"`
+	QuarantinedAt       int64  `json:"quarantined_at,omitempty"` // unix ms
+
+	MutedRooms map[int64]int64 `json:"muted_rooms,omitempty"` // room → unix ms until which the bot is not used there
+
+	accountFailures int // consecutive auth-expired failures
+}
+
+// RecordSend updates a bot's health with the outcome of one Send to room.
+func (p *Pool) RecordSend(name string, room int64, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := p.healthLocked(name)
+	now := time.Now()
+	h.Sends++
+	if err == nil {
+		h.ConsecutiveFailures = 0
+		h.accountFailures = 0
+		h.LastSuccess = now.UnixMilli()
+		delete(h.MutedRooms, room)
+		return
+	}
+
+	kind := Classify(err)
+	if kind == KindCanceled {
+		return
+	}
+	h.Failures++
+	h.ConsecutiveFailures++
+	h.LastFailure = now.UnixMilli()
+	h.LastError = err.Error()
+	h.LastErrorKind = kind.String()
+	h.LastErrorCode = 0
+	h.LastReason = Reason(err)
+	var se *SendError
+	if errors.As(err, &se) {
+		h.LastErrorCode = se.Code
+	}
+
+	switch kind {
+	case KindRateLimited:
+		h.CooldownUntil = now.Add(rateLimitCooldown).UnixMilli()
+	case KindMuted:
+		if h.MutedRooms == nil {
+			h.MutedRooms = make(map[int64]int64)
+		}
+		h.MutedRooms[room] = now.Add(muteCooldown).UnixMilli()
+		slog.Warn("bot muted in room", "bot", name, "room", room, "err", err)
+	case KindAuthExpired:
+		h.accountFailures++
+		if h.accountFailures >= QuarantineAfter && !h.Quarantined {
+			h.Quarantined = true
+			h.QuarantinedAt = now.UnixMilli()
+			cause := h.LastReason
+			if cause == "" {
+				cause = kind.String()
+			}
+			h.QuarantineReason = fmt.Sprintf("%d consecutive %s errors", h.accountFailures, cause)
+			slog.Warn("bot quarantined", "bot", name, "reason", h.QuarantineReason, "err", err)
+		}
+	}
+}
+
+// Quarantine takes a bot out of rotation until Unquarantine.
+func (p *Pool) Quarantine(name, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := p.healthLocked(name)
+	h.Quarantined = true
+	h.QuarantinedAt = time.Now().UnixMilli()
+	h.QuarantineReason = reason
+	slog.Warn("bot quarantined", "bot", name, "reason", reason)
+}
+
+// Unquarantine puts a bot back into rotation in every room and clears its
+// failure streak.
+func (p *Pool) Unquarantine(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := p.healthLocked(name)
+	h.Quarantined = false
+	h.QuarantineReason = ""
+	h.QuarantinedAt = 0
+	h.CooldownUntil = 0
+	h.ConsecutiveFailures = 0
+	h.accountFailures = 0
+	h.MutedRooms = nil
+	slog.Info("bot released from quarantine", "bot", name)
+}
+
+// CredentialsChanged lifts a quarantine once a bot has new credentials
+// (re-login, cookie refresh), which is what fixes an expired login.
+// Room mutes are kept.
+func (p *Pool) CredentialsChanged(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := p.health[name]
+	if h == nil {
+		return
+	}
+	h.accountFailures = 0
+	if h.Quarantined {
+		h.Quarantined = false
+		h.QuarantineReason = ""
+		h.QuarantinedAt = 0
+		slog.Info("bot released from quarantine: new credentials", "bot", name)
+	}
+}
+
+// Usable reports whether a bot may be picked for sending to room: not
+// quarantined, not muted there and, unless ignoreCooldown, not cooling down
+// after a rate limit.
+func (p *Pool) Usable(name string, room int64, ignoreCooldown bool) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	h := p.health[name]
+	if h == nil {
+		return true
+	}
+	if h.Quarantined {
+		return false
+	}
+	now := time.Now().UnixMilli()
+	if now < h.MutedRooms[room] {
+		return false
+	}
+	return ignoreCooldown || now >= h.CooldownUntil
+}
+
+// Health returns the health of every bot that has one, keyed by name.
+func (p *Pool) Health() map[string]Health {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make(map[string]Health, len(p.bots))
+	for name := range p.bots {
+		if h := p.health[name]; h != nil {
+			c := *h
+			c.MutedRooms = maps.Clone(h.MutedRooms)
+			out[name] = c
+		} else {
+			out[name] = Health{}
+		}
+	}
+	return out
+}
+
+// healthLocked returns the health record of name, creating it.
+// Caller must hold p.mu.
+func (p *Pool) healthLocked(name string) *Health {
+	h := p.health[name]
+	if h == nil {
+		h = &Health{}
+		p.health[name] = h
+	}
+	return h
+}
@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"context"
+	"time"
+)
+
+// Message describes what a Send call delivers, for bots that forward more
+// than the chunk text (e.g. webhooks). The controller attaches it to the
+// ctx passed to Send.
+type Message struct {
+	Streamer   string
+	Output     string
+	Channel    string // target channel for platforms addressed by name (Twitch)
+	ID         int64  // pending message ID
+	Seq        int    // translation sequence number, shared by all outputs (0 for operator messages)
+	SourceText string
+	SourceLang string
+	TargetLang string
+	Text       string // full translated text, before prefix/suffix and splitting
+	Manual     bool   // operator-injected
+	ReceivedAt time.Time
+	Chunk      int // index of the chunk being sent
+	Chunks     int // number of chunks
+}
+
+type messageKey struct{}
+
+// WithMessage returns ctx carrying m.
+func WithMessage(ctx context.Context, m Message) context.Context {
+	return context.WithValue(ctx, messageKey{}, m)
+}
+
+// MessageFrom returns the Message attached to ctx by WithMessage.
+func MessageFrom(ctx context.Context) (Message, bool) {
+	m, ok := ctx.Value(messageKey{}).(Message)
+	return m, ok
+}
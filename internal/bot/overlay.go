@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/christian-lee/livesub/internal/config"
+)
+
+// overlayBacklog is how many recent lines a newly connected overlay page
+// receives, so a reloaded browser source is not blank.
+const overlayBacklog = 10
+
+func init() {
+	RegisterSink("overlay", func(o config.OutputConfig) (Bot, error) {
+		return NewOverlayBot(o.Name, o.Overlay.Token, o.MaxLen)
+	})
+}
+
+// OverlayLine is one subtitle line pushed to overlay pages.
+type OverlayLine struct {
+	Text string `json:"text"`
+	Time int64  `json:"time"` // unix ms
+}
+
+// overlayHub fans lines out to the pages subscribed to a token.
+var overlayHub = struct {
+	mu     sync.Mutex
+	subs   map[string]map[chan OverlayLine]bool // token → subscribers
+	recent map[string][]OverlayLine             // token → last overlayBacklog lines
+}{
+	subs:   make(map[string]map[chan OverlayLine]bool),
+	recent: make(map[string][]OverlayLine),
+}
+
+// SubscribeOverlay returns the recent lines of token and a channel of new
+// ones. cancel must be called when the page disconnects. Lines are dropped
+// for subscribers that fall behind.
+func SubscribeOverlay(token string) (recent []OverlayLine, lines <-chan OverlayLine, cancel func()) {
+	ch := make(chan OverlayLine, 16)
+	overlayHub.mu.Lock()
+	defer overlayHub.mu.Unlock()
+	if overlayHub.subs[token] == nil {
+		overlayHub.subs[token] = make(map[chan OverlayLine]bool)
+	}
+	overlayHub.subs[token][ch] = true
+	recent = append([]OverlayLine(nil), overlayHub.recent[token]...)
+	return recent, ch, func() {
+		overlayHub.mu.Lock()
+		defer overlayHub.mu.Unlock()
+		delete(overlayHub.subs[token], ch)
+		if len(overlayHub.subs[token]) == 0 {
+			delete(overlayHub.subs, token)
+		}
+	}
+}
+
+func publishOverlay(token string, line OverlayLine) {
+	overlayHub.mu.Lock()
+	defer overlayHub.mu.Unlock()
+	recent := append(overlayHub.recent[token], line)
+	if len(recent) > overlayBacklog {
+		recent = recent[len(recent)-overlayBacklog:]
+	}
+	overlayHub.recent[token] = recent
+	for ch := range overlayHub.subs[token] {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// OverlayBot shows messages as subtitles on the overlay pages opened with
+// its token (an OBS browser source).
+type OverlayBot struct {
+	name   string
+	token  string
+	maxLen int
+}
+
+// NewOverlayBot creates an overlay sink. token authenticates the page.
+func NewOverlayBot(name, token string, maxLen int) (*OverlayBot, error) {
+	if token == "" {
+		return nil, fmt.Errorf("overlay sink %q: token not configured", name)
+	}
+	return &OverlayBot{name: name, token: token, maxLen: maxLen}, nil
+}
+
+func (b *OverlayBot) Send(ctx context.Context, roomID int64, msg string) error {
+	publishOverlay(b.token, OverlayLine{Text: msg, Time: time.Now().UnixMilli()})
+	return nil
+}
+
+func (b *OverlayBot) Platform() string   { return "overlay" }
+func (b *OverlayBot) Name() string       { return b.name }
+func (b *OverlayBot) Available() bool    { return true }
+func (b *OverlayBot) MaxMessageLen() int { return b.maxLen }
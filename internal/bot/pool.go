@@ -2,15 +2,16 @@ package bot
 
 import "sync"
 
-// Pool manages a collection of bots, indexed by name.
+// Pool manages a collection of bots, indexed by name, with their send health.
 type Pool struct {
-	mu   sync.RWMutex
-	bots map[string]Bot
+	mu     sync.RWMutex
+	bots   map[string]Bot
+	health map[string]*Health // by bot name, kept across Remove/Add
 }
 
 // NewPool creates an empty bot pool.
 func NewPool() *Pool {
-	return &Pool{bots: make(map[string]Bot)}
+	return &Pool{bots: make(map[string]Bot), health: make(map[string]*Health)}
 }
 
 // Add registers a bot by name.
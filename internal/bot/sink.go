@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/christian-lee/livesub/internal/config"
+)
+
+// SinkFactory creates the bot for an output whose platform delivers
+// messages itself instead of through pooled accounts.
+type SinkFactory func(o config.OutputConfig) (Bot, error)
+
+var (
+	sinksMu sync.RWMutex
+	sinks   = make(map[string]SinkFactory)
+)
+
+// RegisterSink makes a sink platform available for OutputConfig.Platform.
+func RegisterSink(platform string, f SinkFactory) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks[platform] = f
+}
+
+// IsSink reports whether platform is served by a registered sink.
+func IsSink(platform string) bool {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	_, ok := sinks[platform]
+	return ok
+}
+
+// SinkPlatforms returns the registered sink platform names, sorted.
+func SinkPlatforms() []string {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	out := make([]string, 0, len(sinks))
+	for name := range sinks {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// NewSink creates the sink bot for an output.
+func NewSink(o config.OutputConfig) (Bot, error) {
+	sinksMu.RLock()
+	f, ok := sinks[o.Platform]
+	sinksMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown sink platform %q", o.Platform)
+	}
+	return f(o)
+}
+
+func init() {
+	RegisterSink("console", func(o config.OutputConfig) (Bot, error) {
+		return NewConsoleBot(o.Name, os.Stdout, o.MaxLen), nil
+	})
+	RegisterSink("file", func(o config.OutputConfig) (Bot, error) {
+		return NewFileBot(o.Name, o.Path, o.MaxLen)
+	})
+	RegisterSink("null", func(o config.OutputConfig) (Bot, error) {
+		return NewNullBot(o.Name, o.MaxLen), nil
+	})
+}
+
+// FileBot appends every message as a JSON line to a file.
+type FileBot struct {
+	name   string
+	maxLen int
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// fileRecord is one line written by FileBot.
+type fileRecord struct {
+	Time   string `json:"time"`
+	Output string `json:"output"`
+	Room   int64  `json:"room"`
+	Chunk  string `json:"chunk"`
+}
+
+// NewFileBot opens (or creates) path for appending.
+func NewFileBot(name, path string, maxLen int) (*FileBot, error) {
+	if path == "" {
+		return nil, fmt.Errorf("file sink %q: path not configured", name)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("file sink %q: %w", name, err)
+	}
+	return &FileBot{name: name, maxLen: maxLen, f: f}, nil
+}
+
+func (b *FileBot) Send(ctx context.Context, roomID int64, msg string) error {
+	line, err := json.Marshal(fileRecord{
+		Time:   time.Now().Format(time.RFC3339Nano),
+		Output: b.name,
+		Room:   roomID,
+		Chunk:  msg,
+	})
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err = b.f.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the file.
+func (b *FileBot) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.f.Close()
+}
+
+func (b *FileBot) Platform() string   { return "file" }
+func (b *FileBot) Name() string       { return b.name }
+func (b *FileBot) Available() bool    { return true }
+func (b *FileBot) MaxMessageLen() int { return b.maxLen }
+
+// NullBot discards messages, only counting them.
+type NullBot struct {
+	name   string
+	maxLen int
+	count  atomic.Int64
+}
+
+// NewNullBot creates a counting sink.
+func NewNullBot(name string, maxLen int) *NullBot {
+	return &NullBot{name: name, maxLen: maxLen}
+}
+
+func (b *NullBot) Send(ctx context.Context, roomID int64, msg string) error {
+	b.count.Add(1)
+	return nil
+}
+
+// Count returns the number of messages received.
+func (b *NullBot) Count() int64 { return b.count.Load() }
+
+// Close logs the message count.
+func (b *NullBot) Close() error {
+	slog.Info("null sink closed", "output", b.name, "messages", b.Count())
+	return nil
+}
+
+func (b *NullBot) Platform() string   { return "null" }
+func (b *NullBot) Name() string       { return b.name }
+func (b *NullBot) Available() bool    { return true }
+func (b *NullBot) MaxMessageLen() int { return b.maxLen }
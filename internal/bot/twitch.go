@@ -0,0 +1,368 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Twitch chat limits for accounts that are not moderators in the channel.
+const (
+	TwitchMaxMessageLen   = 500
+	twitchDefaultServer   = "irc.chat.twitch.tv:6697"
+	twitchChannelInterval = 1100 * time.Millisecond // one message per second per channel
+	twitchWindow          = 30 * time.Second        // at most twitchWindowMessages per window per account
+	twitchWindowMessages  = 20
+	twitchDialTimeout     = 10 * time.Second
+	twitchMaxWait         = 3 * time.Second // longer waits report KindRateLimited so the pool fails over
+)
+
+// TwitchOption configures a TwitchBot.
+type TwitchOption func(*TwitchBot)
+
+// WithTwitchServer connects to addr instead of Twitch, over TLS unless
+// plain (e.g. a local IRC stand-in for testing).
+func WithTwitchServer(addr string, plain bool) TwitchOption {
+	return func(b *TwitchBot) {
+		if addr != "" {
+			b.addr = addr
+		}
+		b.plain = plain
+	}
+}
+
+// WithTwitchRateLimit overrides the per-channel interval and the
+// per-account message budget per window.
+func WithTwitchRateLimit(perChannel time.Duration, messages int, window time.Duration) TwitchOption {
+	return func(b *TwitchBot) {
+		b.perChannel = perChannel
+		b.windowMessages = messages
+		b.window = window
+	}
+}
+
+// TwitchBot posts to Twitch chat over IRC. The target channel comes from
+// the Message in the Send ctx (OutputConfig.Channel). One connection is
+// kept open and re-dialled when it drops.
+type TwitchBot struct {
+	name  string
+	login string
+	token string
+	addr  string
+	plain bool
+
+	perChannel     time.Duration
+	window         time.Duration
+	windowMessages int
+
+	mu       sync.Mutex // serialises Send
+	conn     *twitchConn
+	lastSent map[string]time.Time // channel → last PRIVMSG
+	sent     []time.Time          // PRIVMSGs within the window
+}
+
+// twitchConn is one IRC session.
+type twitchConn struct {
+	c       net.Conn
+	writeMu sync.Mutex
+	joined  map[string]bool
+	done    chan struct{} // closed when the reader exits
+
+	noticeMu sync.Mutex
+	notices  map[string]error // channel → moderation notice for the next Send
+}
+
+// NewTwitchBot creates a Twitch chat bot. token is the chat OAuth token
+// (without "oauth:").
+func NewTwitchBot(name, login, token string, opts ...TwitchOption) *TwitchBot {
+	b := &TwitchBot{
+		name:           name,
+		login:          strings.ToLower(login),
+		token:          strings.TrimPrefix(token, "oauth:"),
+		addr:           twitchDefaultServer,
+		perChannel:     twitchChannelInterval,
+		window:         twitchWindow,
+		windowMessages: twitchWindowMessages,
+		lastSent:       make(map[string]time.Time),
+	}
+	for _, o := range opts {
+		o(b)
+	}
+	return b
+}
+
+func (b *TwitchBot) Platform() string   { return "twitch" }
+func (b *TwitchBot) Name() string       { return b.name }
+func (b *TwitchBot) Available() bool    { return b.token != "" && b.login != "" }
+func (b *TwitchBot) MaxMessageLen() int { return TwitchMaxMessageLen }
+
+// Send posts msg to the channel named in the ctx Message. roomID is unused.
+func (b *TwitchBot) Send(ctx context.Context, roomID int64, msg string) error {
+	m, _ := MessageFrom(ctx)
+	channel := strings.ToLower(strings.TrimPrefix(m.Channel, "#"))
+	if channel == "" {
+		return &SendError{Kind: KindContentRejected, Err: fmt.Errorf("twitch: no channel configured for output %q", m.Output)}
+	}
+	msg = strings.Join(strings.Fields(msg), " ") // IRC lines can't carry newlines
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.waitRateLimit(ctx, channel); err != nil {
+		return err
+	}
+	conn, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	if err := conn.takeNotice(channel); err != nil {
+		return err
+	}
+	if !conn.joined[channel] {
+		if err := conn.writeLine("JOIN #" + channel); err != nil {
+			b.dropConn(conn)
+			return &SendError{Kind: KindTransient, Err: err}
+		}
+		conn.joined[channel] = true
+	}
+	if err := conn.writeLine("PRIVMSG #" + channel + " :" + msg); err != nil {
+		b.dropConn(conn)
+		return &SendError{Kind: KindTransient, Err: err}
+	}
+	now := time.Now()
+	b.lastSent[channel] = now
+	b.sent = append(b.sent, now)
+	return nil
+}
+
+// Close disconnects from the server.
+func (b *TwitchBot) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn != nil {
+		b.dropConn(b.conn)
+	}
+	return nil
+}
+
+// waitRateLimit blocks until a message may be sent to channel. If that is
+// more than twitchMaxWait away (the account's window is used up), it
+// returns a KindRateLimited error instead of holding every output that
+// sends through this account. Caller must hold b.mu.
+func (b *TwitchBot) waitRateLimit(ctx context.Context, channel string) error {
+	for {
+		now := time.Now()
+		cutoff := now.Add(-b.window)
+		for len(b.sent) > 0 && b.sent[0].Before(cutoff) {
+			b.sent = b.sent[1:]
+		}
+		var wait time.Duration
+		if last, ok := b.lastSent[channel]; ok {
+			wait = last.Add(b.perChannel).Sub(now)
+		}
+		if b.windowMessages > 0 && len(b.sent) >= b.windowMessages {
+			if w := b.sent[0].Add(b.window).Sub(now); w > wait {
+				wait = w
+			}
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if wait > twitchMaxWait {
+			return &SendError{Kind: KindRateLimited, Err: fmt.Errorf("twitch: message limit reached, next slot in %s", wait.Round(time.Second))}
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// connect returns the open connection, dialling and logging in if needed.
+// Caller must hold b.mu.
+func (b *TwitchBot) connect(ctx context.Context) (*twitchConn, error) {
+	if b.conn != nil {
+		select {
+		case <-b.conn.done:
+			b.conn = nil
+		default:
+			return b.conn, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, twitchDialTimeout)
+	defer cancel()
+	var c net.Conn
+	var err error
+	if b.plain {
+		c, err = (&net.Dialer{}).DialContext(ctx, "tcp", b.addr)
+	} else {
+		c, err = (&tls.Dialer{}).DialContext(ctx, "tcp", b.addr)
+	}
+	if err != nil {
+		return nil, &SendError{Kind: KindTransient, Err: fmt.Errorf("twitch: dial %s: %w", b.addr, err)}
+	}
+
+	conn := &twitchConn{
+		c:       c,
+		joined:  make(map[string]bool),
+		done:    make(chan struct{}),
+		notices: make(map[string]error),
+	}
+	welcome := make(chan error, 1)
+	go conn.read(b.name, welcome)
+
+	for _, line := range []string{
+		"CAP REQ :twitch.tv/tags twitch.tv/commands",
+		"PASS oauth:" + b.token,
+		"NICK " + b.login,
+	} {
+		if err := conn.writeLine(line); err != nil {
+			c.Close()
+			return nil, &SendError{Kind: KindTransient, Err: err}
+		}
+	}
+
+	select {
+	case err := <-welcome:
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+	case <-ctx.Done():
+		c.Close()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, &SendError{Kind: KindTransient, Err: fmt.Errorf("twitch: login timed out")}
+		}
+		return nil, ctx.Err()
+	}
+	slog.Info("twitch connected", "bot", b.name, "login", b.login, "server", b.addr)
+	b.conn = conn
+	return conn, nil
+}
+
+// dropConn closes conn and forgets it. Caller must hold b.mu.
+func (b *TwitchBot) dropConn(conn *twitchConn) {
+	conn.c.Close()
+	if b.conn == conn {
+		b.conn = nil
+	}
+}
+
+func (tc *twitchConn) writeLine(line string) error {
+	tc.writeMu.Lock()
+	defer tc.writeMu.Unlock()
+	tc.c.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := tc.c.Write([]byte(line + "\r\n"))
+	return err
+}
+
+// takeNotice returns and clears a moderation notice received for channel.
+func (tc *twitchConn) takeNotice(channel string) error {
+	tc.noticeMu.Lock()
+	defer tc.noticeMu.Unlock()
+	err := tc.notices[channel]
+	delete(tc.notices, channel)
+	return err
+}
+
+// read handles server lines until the connection closes: answers PINGs,
+// reports the login result on welcome and records moderation notices.
+func (tc *twitchConn) read(botName string, welcome chan<- error) {
+	defer close(tc.done)
+	loggedIn := false
+	sc := bufio.NewScanner(tc.c)
+	for sc.Scan() {
+		m := parseIRC(sc.Text())
+		switch m.command {
+		case "PING":
+			tc.writeLine("PONG :" + m.trailing)
+		case "001":
+			if !loggedIn {
+				loggedIn = true
+				welcome <- nil
+			}
+		case "RECONNECT":
+			slog.Info("twitch asked to reconnect", "bot", botName)
+			tc.c.Close()
+		case "NOTICE":
+			if !loggedIn {
+				// Login failures arrive as a NOTICE before 001
+				welcome <- &SendError{Kind: KindAuthExpired, Err: fmt.Errorf("twitch: %s", m.trailing)}
+				tc.c.Close()
+				return
+			}
+			slog.Warn("twitch notice", "bot", botName, "channel", m.channel(), "msg_id", m.tags["msg-id"], "text", m.trailing)
+			if kind, ok := twitchNoticeKinds[m.tags["msg-id"]]; ok {
+				tc.noticeMu.Lock()
+				tc.notices[m.channel()] = &SendError{Kind: kind, Err: fmt.Errorf("twitch: %s", m.trailing)}
+				tc.noticeMu.Unlock()
+			}
+		}
+	}
+	if !loggedIn {
+		welcome <- &SendError{Kind: KindTransient, Err: fmt.Errorf("twitch: connection closed before login")}
+	}
+}
+
+// twitchNoticeKinds maps NOTICE msg-ids about a refused PRIVMSG to error
+// kinds. Twitch does not acknowledge messages, so the notice is returned by
+// the next Send to the channel.
+var twitchNoticeKinds = map[string]ErrorKind{
+	"msg_banned":            KindMuted,
+	"msg_timedout":          KindMuted,
+	"msg_channel_suspended": KindMuted,
+	"msg_verified_email":    KindMuted,
+	"msg_ratelimit":         KindRateLimited,
+	"msg_slowmode":          KindRateLimited,
+	"msg_duplicate":         KindContentRejected,
+	"msg_rejected":          KindContentRejected,
+}
+
+// ircMessage is a parsed IRC line.
+type ircMessage struct {
+	tags     map[string]string
+	command  string
+	params   []string
+	trailing string
+}
+
+// channel returns the first parameter without '#'.
+func (m ircMessage) channel() string {
+	if len(m.params) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(m.params[0], "#")
+}
+
+// parseIRC parses "[@tags] [:prefix] COMMAND [params] [:trailing]".
+func parseIRC(line string) ircMessage {
+	var m ircMessage
+	if strings.HasPrefix(line, "@") {
+		tags, rest, _ := strings.Cut(line[1:], " ")
+		m.tags = make(map[string]string)
+		for _, kv := range strings.Split(tags, ";") {
+			k, v, _ := strings.Cut(kv, "=")
+			m.tags[k] = v
+		}
+		line = rest
+	}
+	if strings.HasPrefix(line, ":") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+	line, m.trailing, _ = strings.Cut(line, " :")
+	fields := strings.Fields(line)
+	if len(fields) > 0 {
+		m.command = fields[0]
+		m.params = fields[1:]
+	}
+	return m
+}
@@ -0,0 +1,186 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/christian-lee/livesub/internal/config"
+)
+
+// Webhook defaults, see config.WebhookConfig.
+const (
+	webhookTimeout = 5 * time.Second
+	webhookRetries = 2
+	webhookBackoff = time.Second
+)
+
+func init() {
+	RegisterSink("webhook", func(o config.OutputConfig) (Bot, error) {
+		return NewWebhookBot(o.Name, o.Webhook, o.MaxLen)
+	})
+}
+
+// webhookPayload is the JSON body POSTed for every chunk.
+type webhookPayload struct {
+	Streamer   string `json:"streamer"`
+	Output     string `json:"output"`
+	ID         int64  `json:"id"`
+	Seq        int    `json:"seq"`
+	Chunk      int    `json:"chunk"`
+	Chunks     int    `json:"chunks"`
+	Room       int64  `json:"room_id"`
+	SourceText string `json:"source_text"`
+	SourceLang string `json:"source_lang"`
+	TargetLang string `json:"target_lang"`
+	Text       string `json:"text"`    // full translation
+	Message    string `json:"message"` // this chunk as sent, with prefix/suffix
+	Manual     bool   `json:"manual,omitempty"`
+	ReceivedAt string `json:"received_at,omitempty"` // translation reached the controller
+	SentAt     string `json:"sent_at"`
+}
+
+// WebhookBot POSTs every message as JSON to a URL. Bodies are signed with
+// HMAC-SHA256 over "<timestamp>.<body>" when a secret is set:
+//
+//	X-LiveSub-Timestamp: <unix seconds>
+//	X-LiveSub-Signature: sha256=<hex>
+type WebhookBot struct {
+	name    string
+	url     string
+	secret  []byte
+	retries int
+	maxLen  int
+	client  *http.Client
+}
+
+// NewWebhookBot creates a webhook sink.
+func NewWebhookBot(name string, cfg config.WebhookConfig, maxLen int) (*WebhookBot, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("webhook sink %q: url not configured", name)
+	}
+	timeout := webhookTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	retries := webhookRetries
+	if cfg.Retries != nil && *cfg.Retries >= 0 {
+		retries = *cfg.Retries
+	}
+	return &WebhookBot{
+		name:    name,
+		url:     cfg.URL,
+		secret:  []byte(cfg.Secret),
+		retries: retries,
+		maxLen:  maxLen,
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Send POSTs msg, retrying network errors, 429 and 5xx with backoff.
+// The returned *SendError is marked Retried so callers don't retry again.
+func (b *WebhookBot) Send(ctx context.Context, roomID int64, msg string) error {
+	p := webhookPayload{
+		Output:  b.name,
+		Room:    roomID,
+		Text:    msg,
+		Message: msg,
+		Chunks:  1,
+		SentAt:  time.Now().Format(time.RFC3339Nano),
+	}
+	if m, ok := MessageFrom(ctx); ok {
+		p.Streamer = m.Streamer
+		p.ID = m.ID
+		p.Seq = m.Seq
+		p.Chunk = m.Chunk
+		p.Chunks = m.Chunks
+		p.SourceText = m.SourceText
+		p.SourceLang = m.SourceLang
+		p.TargetLang = m.TargetLang
+		p.Text = m.Text
+		p.Manual = m.Manual
+		if !m.ReceivedAt.IsZero() {
+			p.ReceivedAt = m.ReceivedAt.Format(time.RFC3339Nano)
+		}
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	backoff := webhookBackoff
+	for attempt := 0; ; attempt++ {
+		code, err := b.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		kind := webhookErrorKind(ctx, code)
+		if kind == KindCanceled {
+			return err
+		}
+		if kind == KindContentRejected || attempt >= b.retries {
+			return &SendError{Kind: kind, Code: code, Err: err, Retried: true}
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// post sends one request. code is the HTTP status, 0 if none was received.
+func (b *WebhookBot) post(ctx context.Context, body []byte) (code int, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", b.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "livesub/1.0")
+	if len(b.secret) > 0 {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, b.secret)
+		mac.Write([]byte(ts + "."))
+		mac.Write(body)
+		req.Header.Set("X-LiveSub-Timestamp", ts)
+		req.Header.Set("X-LiveSub-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook: http %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// webhookErrorKind classifies a failed POST by its HTTP status.
+func webhookErrorKind(ctx context.Context, code int) ErrorKind {
+	switch {
+	case ctx.Err() != nil:
+		return KindCanceled
+	case code == http.StatusTooManyRequests:
+		return KindRateLimited
+	case code == http.StatusRequestTimeout || code == 0 || code >= 500:
+		return KindTransient
+	}
+	return KindContentRejected // other 4xx: the receiver refuses the payload
+}
+
+func (b *WebhookBot) Platform() string   { return "webhook" }
+func (b *WebhookBot) Name() string       { return b.name }
+func (b *WebhookBot) Available() bool    { return true }
+func (b *WebhookBot) MaxMessageLen() int { return b.maxLen }
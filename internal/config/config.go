@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"gopkg.in/yaml.v3"
 )
@@ -15,6 +16,14 @@ type Config struct {
 	Translation TranslationConfig `yaml:"translation" json:"translation"`
 	Bots        []BotConfig       `yaml:"bots" json:"bots"`
 	Web         WebConfig         `yaml:"web" json:"web"`
+	Twitch      TwitchConfig      `yaml:"twitch,omitempty" json:"twitch"`
+}
+
+// TwitchConfig overrides the Twitch chat server, e.g. to run against a
+// local IRC stand-in.
+type TwitchConfig struct {
+	Server string `yaml:"server,omitempty" json:"server,omitempty"` // host:port (default irc.chat.twitch.tv:6697)
+	Plain  bool   `yaml:"plain,omitempty" json:"plain,omitempty"`   // no TLS
 }
 
 type StreamerConfig struct {
@@ -23,31 +32,145 @@ type StreamerConfig struct {
 	SourceLang  string         `yaml:"source_lang" json:"source_lang"`
 	AltLangs    []string       `yaml:"alt_langs" json:"alt_langs"`
 	Outputs     []OutputConfig `yaml:"outputs" json:"outputs"`
-	CommandUIDs []int64        `yaml:"command_uids" json:"command_uids"` // UIDs allowed to send commands via danmaku
+	CommandUIDs []int64        `yaml:"command_uids" json:"command_uids"`                     // UIDs allowed to send commands via danmaku
+	STTProvider string         `yaml:"stt_provider,omitempty" json:"stt_provider,omitempty"` // overrides stt.provider for this streamer
+	Translator  string         `yaml:"translator,omitempty" json:"translator,omitempty"`     // translation backend name ("" = default)
+	STT         STTTuning      `yaml:"stt,omitempty" json:"stt"`                             // per-streamer recognition tuning
+	Filter      FilterConfig   `yaml:"filter,omitempty" json:"filter"`                       // drop noise between STT and translation
+}
+
+// FilterConfig drops STT finals that are not worth translating. Filtered
+// finals are still written to the transcript, flagged with the reason.
+// Zero values disable each check.
+type FilterConfig struct {
+	MinConfidence  float32             `yaml:"min_confidence,omitempty" json:"min_confidence,omitempty"`   // 0-1; results without a confidence are kept
+	MinLength      int                 `yaml:"min_length,omitempty" json:"min_length,omitempty"`           // minimum characters, punctuation and spaces excluded
+	Fillers        map[string][]string `yaml:"fillers,omitempty" json:"fillers,omitempty"`                 // language ("ja", "en", ...) → filler words / laughter
+	DefaultFillers bool                `yaml:"default_fillers,omitempty" json:"default_fillers,omitempty"` // also use the built-in filler lists
+	Dedupe         bool                `yaml:"dedupe,omitempty" json:"dedupe,omitempty"`                   // drop a final identical to the previous one
+}
+
+// STTTuning holds per-streamer recognition options. Zero values keep the
+// provider's defaults.
+type STTTuning struct {
+	Phrases         []string `yaml:"phrases,omitempty" json:"phrases,omitempty"`                     // phrase hints: talent, fan and game names
+	Boost           float32  `yaml:"boost,omitempty" json:"boost,omitempty"`                         // phrase hint boost (0 = provider default, google allows up to 20)
+	Model           string   `yaml:"model,omitempty" json:"model,omitempty"`                         // e.g. "latest_long", "latest_short"
+	Enhanced        bool     `yaml:"enhanced,omitempty" json:"enhanced,omitempty"`                   // use the enhanced model variant
+	ProfanityFilter bool     `yaml:"profanity_filter,omitempty" json:"profanity_filter,omitempty"`   // mask profanity in transcripts
+	WordTimeOffsets bool     `yaml:"word_time_offsets,omitempty" json:"word_time_offsets,omitempty"` // request per-word start/end times
 }
 
 type STTConfig struct {
-	Provider    string `yaml:"provider" json:"provider"`
+	Provider    string `yaml:"provider" json:"provider"` // "google" or "whisper"
 	Credentials string `yaml:"credentials" json:"credentials"`
+	Endpoint    string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"` // whisper server URL (ws:// or http://)
 }
 
 type TranslationConfig struct {
+	Provider string             `yaml:"provider" json:"provider"` // default backend: "gemini", "openai", "libretranslate"
+	APIKey   string             `yaml:"api_key" json:"api_key"`
+	Model    string             `yaml:"model" json:"model"`
+	Endpoint string             `yaml:"endpoint,omitempty" json:"endpoint,omitempty"` // server URL for openai-compatible / libretranslate
+	Backends []TranslatorConfig `yaml:"backends,omitempty" json:"backends,omitempty"` // additional named backends
+
+	// Rolling context: earlier utterances of the same stream included in prompts
+	ContextLines  int `yaml:"context_lines" json:"context_lines"`   // max previous utterances (0 = disabled)
+	ContextTokens int `yaml:"context_tokens" json:"context_tokens"` // approximate token budget for the context
+
+	Batch bool `yaml:"batch" json:"batch"` // one request returning all target languages (JSON), per-language fallback
+
+	CacheSize int `yaml:"cache_size" json:"cache_size"` // in-memory LRU entries in front of the SQLite cache (0 = no cache)
+}
+
+// TranslatorConfig is a named translation backend that streamers and
+// outputs can select via their translator field.
+type TranslatorConfig struct {
+	Name     string `yaml:"name" json:"name"`
 	Provider string `yaml:"provider" json:"provider"`
 	APIKey   string `yaml:"api_key" json:"api_key"`
 	Model    string `yaml:"model" json:"model"`
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
 }
 
 type OutputConfig struct {
 	Name       string   `yaml:"name" json:"name"`
 	Platform   string   `yaml:"platform" json:"platform"`
 	TargetLang string   `yaml:"target_lang" json:"target_lang"`
-	Account    string   `yaml:"account" json:"account"`       // single account (backward compat)
-	Accounts   []string `yaml:"accounts" json:"accounts"`     // account pool for round-robin
+	Account    string   `yaml:"account" json:"account"`   // single account (backward compat)
+	Accounts   []string `yaml:"accounts" json:"accounts"` // account pool for round-robin
 	RoomID     int64    `yaml:"room_id" json:"room_id"`
+	Channel    string   `yaml:"channel,omitempty" json:"channel,omitempty"` // twitch: chat channel (login name)
 	Prefix     string   `yaml:"prefix" json:"prefix"`
 	Suffix     string   `yaml:"suffix" json:"suffix"`
 	ShowSeq    bool     `yaml:"show_seq" json:"show_seq"`
 	AutoStart  bool     `yaml:"auto_start" json:"auto_start"`
+	Translator string   `yaml:"translator,omitempty" json:"translator,omitempty"` // overrides the streamer's translation backend
+
+	// Sink platforms (console, file, null) deliver messages without accounts
+	Path   string `yaml:"path,omitempty" json:"path,omitempty"`       // file sink: JSONL output path
+	MaxLen int    `yaml:"max_len,omitempty" json:"max_len,omitempty"` // sink message length limit for splitting (0 = none)
+
+	Overlay OverlayConfig `yaml:"overlay,omitempty" json:"overlay"` // overlay platform: subtitle page style
+	Webhook WebhookConfig `yaml:"webhook,omitempty" json:"webhook"` // webhook platform: where to POST messages
+
+	Moderation ModerationConfig `yaml:"moderation,omitempty" json:"moderation"` // screens messages before the delay queue
+
+	SendDelay       *int `yaml:"send_delay,omitempty" json:"send_delay,omitempty"`             // seconds in the delay queue (nil = 3)
+	RequireApproval bool `yaml:"require_approval,omitempty" json:"require_approval,omitempty"` // hold every message until an operator sends it
+}
+
+// OverlayConfig is the "overlay" platform: an OBS browser source served at
+// /overlay?token=... that shows the output's messages as subtitles.
+// Zero values use the page defaults.
+type OverlayConfig struct {
+	Token    string `yaml:"token,omitempty" json:"token,omitempty"`         // authenticates the page; generated when saved from the admin panel
+	Font     string `yaml:"font,omitempty" json:"font,omitempty"`           // CSS font-family
+	FontSize int    `yaml:"font_size,omitempty" json:"font_size,omitempty"` // px (default 36)
+	Color    string `yaml:"color,omitempty" json:"color,omitempty"`         // CSS color (default white)
+	Position string `yaml:"position,omitempty" json:"position,omitempty"`   // "bottom" (default) or "top"
+	Lines    int    `yaml:"lines,omitempty" json:"lines,omitempty"`         // lines on screen (default 2)
+	Fade     int    `yaml:"fade,omitempty" json:"fade,omitempty"`           // seconds before a line fades out (0 = stays until pushed off)
+}
+
+// WebhookConfig is the "webhook" platform: every message is POSTed as JSON
+// to URL, signed with Secret if set.
+type WebhookConfig struct {
+	URL     string `yaml:"url,omitempty" json:"url,omitempty"`
+	Secret  string `yaml:"secret,omitempty" json:"secret,omitempty"`   // HMAC-SHA256 key for the X-LiveSub-Signature header
+	Timeout int    `yaml:"timeout,omitempty" json:"timeout,omitempty"` // seconds per attempt (default 5)
+	Retries *int   `yaml:"retries,omitempty" json:"retries,omitempty"` // extra attempts on network errors, 429 and 5xx (nil = 2)
+}
+
+// ModerationConfig screens an output's messages before they are queued.
+type ModerationConfig struct {
+	Words    []string `yaml:"words,omitempty" json:"words,omitempty"`       // blocked words (case-insensitive)
+	Patterns []string `yaml:"patterns,omitempty" json:"patterns,omitempty"` // blocked regular expressions
+	Lists    []string `yaml:"lists,omitempty" json:"lists,omitempty"`       // word list files, one word per line (e.g. Bilibili sensitive words)
+	Action   string   `yaml:"action,omitempty" json:"action,omitempty"`     // "drop", "mask" or "hold" (default)
+}
+
+// Validate reports an unknown action, a pattern that does not compile or
+// a list file that cannot be opened.
+func (m ModerationConfig) Validate() error {
+	switch m.Action {
+	case "", "drop", "mask", "hold":
+	default:
+		return fmt.Errorf("unknown moderation action %q", m.Action)
+	}
+	for _, p := range m.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("moderation pattern %q: %w", p, err)
+		}
+	}
+	for _, path := range m.Lists {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("moderation list: %w", err)
+		}
+		f.Close()
+	}
+	return nil
 }
 
 // AccountPool returns the effective list of accounts for this output.
@@ -62,6 +185,18 @@ func (o *OutputConfig) AccountPool() []string {
 	return nil
 }
 
+// CheckAccountPlatforms returns an error if an account of the pool belongs
+// to another platform than the output. platformOf returns an account's
+// platform, or "" if it is unknown.
+func (o *OutputConfig) CheckAccountPlatforms(platformOf func(name string) string) error {
+	for _, name := range o.AccountPool() {
+		if p := platformOf(name); p != "" && p != o.Platform {
+			return fmt.Errorf("output %q: account %q is a %s account, not %s", o.Name, name, p, o.Platform)
+		}
+	}
+	return nil
+}
+
 type BotConfig struct {
 	Name       string `yaml:"name" json:"name"`
 	Platform   string `yaml:"platform" json:"platform"`
@@ -72,8 +207,9 @@ type BotConfig struct {
 }
 
 type WebConfig struct {
-	Port int        `yaml:"port" json:"port"`
-	Auth AuthConfig `yaml:"auth" json:"auth"`
+	Port         int        `yaml:"port" json:"port"`
+	Auth         AuthConfig `yaml:"auth" json:"auth"`
+	AlertWebhook string     `yaml:"alert_webhook,omitempty" json:"alert_webhook,omitempty"` // POSTed JSON when a Bilibili account stops being logged in
 }
 
 type AuthConfig struct {
@@ -92,8 +228,11 @@ func Load(path string) (*Config, error) {
 			Provider: "google",
 		},
 		Translation: TranslationConfig{
-			Provider: "gemini",
-			Model:    "gemini-2.0-flash",
+			Provider:      "gemini",
+			Model:         "gemini-2.0-flash",
+			ContextLines:  6,
+			ContextTokens: 400,
+			CacheSize:     2000,
 		},
 		Web: WebConfig{
 			Port: 8899,
@@ -154,6 +293,22 @@ func Load(path string) (*Config, error) {
 		}
 	}
 
+	// An output sends through one platform; its pool cannot mix in other bots
+	botPlatform := make(map[string]string)
+	for _, bc := range cfg.Bots {
+		botPlatform[bc.Name] = bc.Platform
+	}
+	for _, s := range cfg.Streamers {
+		for _, o := range s.Outputs {
+			if err := o.CheckAccountPlatforms(func(name string) string { return botPlatform[name] }); err != nil {
+				return nil, fmt.Errorf("streamer %q: %w", s.Name, err)
+			}
+			if err := o.Moderation.Validate(); err != nil {
+				return nil, fmt.Errorf("streamer %q: output %q: %w", s.Name, o.Name, err)
+			}
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -2,7 +2,11 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,7 +21,12 @@ type Translation struct {
 	Seq        int               // sequence number for ordering
 	SourceText string            // original STT text
 	SourceLang string            // detected language code
-	Texts      map[string]string // target_lang → translated text (empty key = source text)
+	Confidence float32           // STT confidence
+	At         time.Time         // when STT returned the final
+	Texts      map[string]string // TextKey(output) → translated text (empty key = source text)
+
+	// Every translation attempt with its backend and model, for the transcript
+	Results []transcript.Translation
 }
 
 // PendingMsg is a message waiting to be sent (with delay for review).
@@ -26,8 +35,32 @@ type PendingMsg struct {
 	Text      string `json:"text"`
 	SendAt    int64  `json:"send_at"`     // unix ms
 	RemainSec int    `json:"remain_sec"`  // computed at read time
+	Held      bool   `json:"held,omitempty"`   // waiting for operator approval, no countdown
+	Reason    string `json:"reason,omitempty"` // why it is held (matched words)
+	Edited    bool   `json:"edited,omitempty"`
+	Original  string `json:"original,omitempty"`  // text before the first edit
+	EditedBy  string `json:"edited_by,omitempty"` // operator of the last edit
+	Author    string `json:"author,omitempty"`    // operator who injected it ("" = translation)
+}
+
+// FailedMsg is a message that could not be delivered after retries and
+// account failover. It can be resent from the web UI.
+type FailedMsg struct {
+	ID     int64  `json:"id"`
+	Text   string `json:"text"`
+	Kind   string `json:"kind"`             // bot.ErrorKind of the last error
+	Reason string `json:"reason,omitempty"` // platform reason key (bot.Reason)
+	Error  string `json:"error"`
+	At     int64  `json:"at"` // unix ms
+
+	chunks []string // unsent chunks, nil = all
+	src    msgSource
 }
 
+// ReasonApproval is the PendingMsg.Reason of messages held because their
+// output requires approval for everything.
+const ReasonApproval = "approval"
+
 // OutputState tracks per-output status for the web UI.
 type OutputState struct {
 	Name       string       `json:"name"`
@@ -39,8 +72,11 @@ type OutputState struct {
 	Paused     bool         `json:"paused"`
 	ShowSeq    bool         `json:"show_seq"`
 	AutoStart  bool         `json:"auto_start"`
+	SendDelay  int          `json:"send_delay"`        // seconds
+	Approval   bool         `json:"require_approval"` // every message waits for an operator
 	LastText   string       `json:"last_text"`
 	Pending    []PendingMsg `json:"pending"` // messages waiting to send
+	Failed     []FailedMsg  `json:"failed"`  // messages that could not be delivered
 	Recent     []string     `json:"recent"`  // last N sent messages
 }
 
@@ -52,21 +88,180 @@ type Controller struct {
 	outputs        []config.OutputConfig
 	tlog           *transcript.Logger
 	streamerRoomID int64
+	streamerName   string // for bot.Message; empty until SetStreamerName
 
 	mu           sync.RWMutex
 	paused       map[string]bool // output name → paused
 	outputStates map[string]*OutputState
 	skipSet      map[int64]bool // pending msg IDs to skip
+	approveSet   map[int64]bool // held msg IDs approved for sending
+	released     map[int64]time.Time // approval-held msg IDs given a countdown when approval was turned off
+	edits        map[int64]pendingEdit // operator edits not yet applied to the delay queue
 	nextMsgID    int64
 
-	sendDelay  time.Duration // delay before sending (default 3s)
 	onChange   func()        // called when pending/recent changes
 	rrIndex    map[string]int // output name → round-robin index for account pool
+	sinks      map[string]*outputSink // output name → sink bot (console/file/null/... platforms)
+	moderators map[string]*outputModerator // output name → compiled moderation config
 	ch         chan Translation
+	inject     chan delayedMsg // operator-authored messages for the delay queue
+	records    map[*txRecord]bool // transcript records waiting for outcomes, guarded by recMu
+	recMu      sync.Mutex
+	deliveries map[string]*outputDelivery // output name → sending goroutine; run goroutine only
+	deliveryWG sync.WaitGroup
 	done       chan struct{}
 	wg         sync.WaitGroup
 }
 
+// defaultSendDelay is how long messages wait for review unless the
+// output sets send_delay.
+const defaultSendDelay = 3 * time.Second
+
+// SendDelay returns how long the output's messages wait in the delay queue.
+func SendDelay(o config.OutputConfig) time.Duration {
+	if o.SendDelay == nil || *o.SendDelay < 0 {
+		return defaultSendDelay
+	}
+	return time.Duration(*o.SendDelay) * time.Second
+}
+
+// outputConfig returns the config of an output, nil if unknown.
+// Caller must hold c.mu.
+func (c *Controller) outputConfig(name string) *config.OutputConfig {
+	for i := range c.outputs {
+		if c.outputs[i].Name == name {
+			return &c.outputs[i]
+		}
+	}
+	return nil
+}
+
+// outputSink is the bot of an output on a sink platform, which delivers
+// messages itself instead of through pooled accounts.
+type outputSink struct {
+	bot bot.Bot
+	key string // config the sink was created from
+}
+
+// syncSinks creates, replaces or closes sink bots to match c.outputs.
+// Caller must hold c.mu.
+func (c *Controller) syncSinks() {
+	keep := make(map[string]bool)
+	for _, o := range c.outputs {
+		if !bot.IsSink(o.Platform) {
+			continue
+		}
+		keep[o.Name] = true
+		key := sinkKey(o)
+		if cur, ok := c.sinks[o.Name]; ok {
+			if cur.key == key {
+				continue
+			}
+			closeSink(cur.bot)
+			delete(c.sinks, o.Name)
+		}
+		b, err := bot.NewSink(o)
+		if err != nil {
+			slog.Error("create sink", "output", o.Name, "platform", o.Platform, "err", err)
+			continue
+		}
+		c.sinks[o.Name] = &outputSink{bot: b, key: key}
+	}
+	for name, cur := range c.sinks {
+		if !keep[name] {
+			closeSink(cur.bot)
+			delete(c.sinks, name)
+		}
+	}
+}
+
+// sinkKey identifies the config a sink bot was created from.
+func sinkKey(o config.OutputConfig) string {
+	b, _ := json.Marshal([]any{o.Platform, o.Path, o.MaxLen, o.Overlay.Token, o.Webhook})
+	return string(b)
+}
+
+// outputModerator is the compiled moderation config of an output.
+type outputModerator struct {
+	m   *moderator // nil = moderation disabled
+	key string     // config the moderator was compiled from
+	err error      // config did not compile and there is no previous one: hold everything
+}
+
+// syncModerators compiles moderation configs that changed since the last
+// sync. A config that does not compile fails closed: the output keeps its
+// last working moderator, or holds every message if it has none.
+// Caller must hold c.mu.
+func (c *Controller) syncModerators() {
+	keep := make(map[string]bool)
+	for _, o := range c.outputs {
+		keep[o.Name] = true
+		key := fmt.Sprintf("%v", o.Moderation)
+		if cur, ok := c.moderators[o.Name]; ok && cur.key == key {
+			continue
+		}
+		m, err := newModerator(o.Moderation)
+		if err != nil {
+			slog.Error("moderation config", "output", o.Name, "err", err)
+			if cur, ok := c.moderators[o.Name]; ok && (cur.m != nil || cur.err != nil) {
+				cur.key = key
+				continue
+			}
+		}
+		c.moderators[o.Name] = &outputModerator{m: m, key: key, err: err}
+	}
+	for name := range c.moderators {
+		if !keep[name] {
+			delete(c.moderators, name)
+		}
+	}
+}
+
+// moderate screens text for an output. It returns the text to queue
+// (masked if configured), whether to hold it for approval with the matched
+// words as reason, and false if the message must be dropped.
+func (c *Controller) moderate(output, text string) (string, bool, string, bool) {
+	c.mu.RLock()
+	var m *moderator
+	var cfgErr error
+	if cur := c.moderators[output]; cur != nil {
+		m, cfgErr = cur.m, cur.err
+	}
+	c.mu.RUnlock()
+	if cfgErr != nil {
+		slog.Warn("moderation: held, config error", "output", output, "err", cfgErr, "text", text)
+		return text, true, "moderation config error", true
+	}
+	if m == nil {
+		return text, false, "", true
+	}
+	matched := m.check(text)
+	if len(matched) == 0 {
+		return text, false, "", true
+	}
+	reason := strings.Join(matched, ", ")
+	switch m.action {
+	case ModerationDrop:
+		slog.Warn("moderation: dropped", "output", output, "matched", reason, "text", text)
+		return "", false, reason, false
+	case ModerationMask:
+		masked := m.mask(text)
+		slog.Info("moderation: masked", "output", output, "matched", reason, "text", masked)
+		return masked, false, reason, true
+	default:
+		slog.Warn("moderation: held for approval", "output", output, "matched", reason, "text", text)
+		return text, true, reason, true
+	}
+}
+
+func closeSink(b bot.Bot) {
+	if cl, ok := b.(io.Closer); ok {
+		if err := cl.Close(); err != nil {
+			slog.Warn("close sink", "output", b.Name(), "err", err)
+		}
+	}
+}
+
 // OnChange registers a callback fired when output state changes (pending/sent).
 func (c *Controller) OnChange(fn func()) {
 	c.mu.Lock()
@@ -103,11 +298,13 @@ func New(pool *bot.Pool, outputs []config.OutputConfig, tlog *transcript.Logger,
 			RoomID:     o.RoomID,
 			ShowSeq:    o.ShowSeq,
 			AutoStart:  o.AutoStart,
+			SendDelay:  int(SendDelay(o) / time.Second),
+			Approval:   o.RequireApproval,
 		}
 		paused[o.Name] = false
 	}
 
-	return &Controller{
+	c := &Controller{
 		pool:           pool,
 		outputs:        outputs,
 		tlog:           tlog,
@@ -115,11 +312,26 @@ func New(pool *bot.Pool, outputs []config.OutputConfig, tlog *transcript.Logger,
 		paused:         paused,
 		outputStates:   states,
 		skipSet:        make(map[int64]bool),
+		approveSet:     make(map[int64]bool),
+		released:       make(map[int64]time.Time),
+		edits:          make(map[int64]pendingEdit),
 		rrIndex:        make(map[string]int),
-		sendDelay:      3 * time.Second,
+		sinks:          make(map[string]*outputSink),
+		moderators:     make(map[string]*outputModerator),
 		ch:             make(chan Translation, 100),
+		inject:         make(chan delayedMsg, 16),
+		records:        make(map[*txRecord]bool),
+		deliveries:     make(map[string]*outputDelivery),
 		done:           make(chan struct{}),
 	}
+	c.syncSinks()
+	c.syncModerators()
+	return c
+}
+
+// SetStreamerName names the streamer in sent messages. Call before Start.
+func (c *Controller) SetStreamerName(name string) {
+	c.streamerName = name
 }
 
 // Start begins processing translations. Call Stop to shut down.
@@ -133,10 +345,88 @@ func (c *Controller) Submit(t Translation) {
 	c.ch <- t
 }
 
-// Stop gracefully shuts down the controller.
+// Inject queues operator-authored text on an output. It goes through the
+// output's prefix/suffix, splitting and account rotation like a translation,
+// and is sent even while the output is paused (e.g. a "translation paused"
+// notice). immediate skips the send delay. Returns the pending message ID.
+func (c *Controller) Inject(output, text, author string, immediate bool) (int64, error) {
+	c.mu.Lock()
+	o := c.outputConfig(output)
+	st := c.outputStates[output]
+	if o == nil || st == nil {
+		c.mu.Unlock()
+		return 0, fmt.Errorf("unknown output %q", output)
+	}
+	targetLang := o.TargetLang
+	msgID := c.nextMsgID
+	c.nextMsgID++
+	sendAt := time.Now()
+	if !immediate {
+		sendAt = sendAt.Add(SendDelay(*o))
+	}
+	dm := delayedMsg{
+		id:       msgID,
+		text:     text,
+		sendAt:   sendAt,
+		output:   output,
+		manual:   true,
+		src:      msgSource{at: time.Now()},
+		delivery: transcript.Delivery{TargetLang: targetLang},
+	}
+	if c.tlog != nil {
+		dm.rec = newTxRecord(transcript.Record{
+			Type:    transcript.RecordManual,
+			Seq:     -1,
+			Time:    time.Now().UnixMilli(),
+			Author:  author,
+			Outputs: map[string]*transcript.Delivery{output: {TargetLang: targetLang, Outcome: transcript.OutcomePending, Text: text}},
+		})
+	}
+	select {
+	case c.inject <- dm:
+	default:
+		c.mu.Unlock()
+		return 0, fmt.Errorf("inject queue full")
+	}
+	st.Pending = append(st.Pending, PendingMsg{
+		ID:     msgID,
+		Text:   text,
+		SendAt: sendAt.UnixMilli(),
+		Author: author,
+	})
+	c.mu.Unlock()
+
+	slog.Info("operator message queued", "output", output, "author", author, "immediate", immediate, "text", text)
+	c.notifyChange()
+	return msgID, nil
+}
+
+// RecordFiltered writes an STT result dropped by the noise filter to the
+// transcript, flagged with reason.
+func (c *Controller) RecordFiltered(sourceLang, text string, confidence float32, reason string) {
+	if c.tlog != nil {
+		c.tlog.WriteRecord(transcript.Record{
+			Type:       transcript.RecordFiltered,
+			Seq:        -1,
+			SourceLang: sourceLang,
+			SourceText: text,
+			Confidence: confidence,
+			Filter:     reason,
+		})
+	}
+}
+
+// Stop gracefully shuts down the controller and closes sink bots.
 func (c *Controller) Stop() {
 	close(c.ch)
 	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, cur := range c.sinks {
+		closeSink(cur.bot)
+		delete(c.sinks, name)
+	}
 }
 
 // TogglePause toggles pause state for an output. Returns new paused state.
@@ -178,7 +468,14 @@ func (c *Controller) UpdateOutput(cfg config.OutputConfig) {
 		s.BotNames = accts
 		s.RoomID = cfg.RoomID
 		s.ShowSeq = cfg.ShowSeq
+		s.SendDelay = int(SendDelay(cfg) / time.Second)
+		if s.Approval && !cfg.RequireApproval {
+			c.releaseApprovalHeld(s, SendDelay(cfg))
+		}
+		s.Approval = cfg.RequireApproval
 	}
+	c.syncSinks()
+	c.syncModerators()
 }
 
 // SyncOutputs replaces the full output list, preserving pause state for existing outputs.
@@ -200,6 +497,11 @@ func (c *Controller) SyncOutputs(outputs []config.OutputConfig) {
 			existing.BotNames = accts
 			existing.RoomID = o.RoomID
 			existing.ShowSeq = o.ShowSeq
+			existing.SendDelay = int(SendDelay(o) / time.Second)
+			if existing.Approval && !o.RequireApproval {
+				c.releaseApprovalHeld(existing, SendDelay(o))
+			}
+			existing.Approval = o.RequireApproval
 			newStates[o.Name] = existing
 			newPaused[o.Name] = c.paused[o.Name]
 		} else {
@@ -212,12 +514,16 @@ func (c *Controller) SyncOutputs(outputs []config.OutputConfig) {
 				BotNames:   accts,
 				RoomID:     o.RoomID,
 				ShowSeq:    o.ShowSeq,
+				SendDelay:  int(SendDelay(o) / time.Second),
+				Approval:   o.RequireApproval,
 			}
 			newPaused[o.Name] = true
 		}
 	}
 	c.outputStates = newStates
 	c.paused = newPaused
+	c.syncSinks()
+	c.syncModerators()
 }
 
 // SetShowSeq updates the show_seq flag for an output.
@@ -278,6 +584,146 @@ func (c *Controller) SkipPending(msgID int64) {
 	}
 }
 
+// ApprovePending releases a held message for sending. Returns false if
+// no held message has that ID.
+func (c *Controller) ApprovePending(msgID int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, st := range c.outputStates {
+		for i, p := range st.Pending {
+			if p.ID == msgID && p.Held {
+				c.approveSet[msgID] = true
+				st.Pending[i].Held = false
+				st.Pending[i].SendAt = time.Now().UnixMilli()
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pendingEdit is an operator change to a queued message.
+type pendingEdit struct {
+	text   string
+	editor string
+	sendAt time.Time // new send time, zero for held messages
+}
+
+// EditPending replaces the text of a pending message. With extend 0 the
+// countdown restarts from the full send delay; otherwise the current send
+// time is pushed back by extend. Held messages stay held. Returns the text
+// before the edit, or false if the message is no longer pending.
+func (c *Controller) EditPending(msgID int64, text, editor string, extend time.Duration) (string, bool) {
+	c.mu.Lock()
+	var prev string
+	found := false
+	now := time.Now()
+	for _, st := range c.outputStates {
+		for i := range st.Pending {
+			p := &st.Pending[i]
+			if p.ID != msgID {
+				continue
+			}
+			found = true
+			prev = p.Text
+			if !p.Edited {
+				p.Original = p.Text
+			}
+			p.Text = text
+			p.Edited = true
+			p.EditedBy = editor
+
+			var sendAt time.Time
+			if !p.Held {
+				if extend > 0 {
+					base := time.UnixMilli(p.SendAt)
+					if base.Before(now) {
+						base = now
+					}
+					sendAt = base.Add(extend)
+				} else if o := c.outputConfig(st.Name); o != nil {
+					sendAt = now.Add(SendDelay(*o))
+				} else {
+					sendAt = now.Add(defaultSendDelay)
+				}
+				p.SendAt = sendAt.UnixMilli()
+			}
+			c.edits[msgID] = pendingEdit{text: text, editor: editor, sendAt: sendAt}
+			break
+		}
+		if found {
+			break
+		}
+	}
+	c.mu.Unlock()
+	if !found {
+		return "", false
+	}
+
+	slog.Info("pending message edited", "id", msgID, "by", editor, "from", prev, "to", text)
+	c.notifyChange()
+	return prev, true
+}
+
+// applyEdit applies a pending operator edit to a queued message.
+// Caller must hold c.mu.
+func (c *Controller) applyEdit(dm *delayedMsg) {
+	e, ok := c.edits[dm.id]
+	if !ok {
+		return
+	}
+	delete(c.edits, dm.id)
+	if !dm.delivery.Edited {
+		dm.delivery.Original = dm.text
+	}
+	dm.delivery.Edited = true
+	dm.delivery.EditedBy = e.editor
+	dm.text = e.text
+	if !dm.held && !e.sendAt.IsZero() {
+		dm.sendAt = e.sendAt
+	}
+}
+
+// releaseApprovalHeld gives the messages of st held only for approval the
+// output's normal countdown, once approval is turned off. Messages held by
+// moderation stay held. Caller must hold c.mu.
+func (c *Controller) releaseApprovalHeld(st *OutputState, delay time.Duration) {
+	sendAt := time.Now().Add(delay)
+	for i := range st.Pending {
+		p := &st.Pending[i]
+		if !p.Held || p.Reason != ReasonApproval {
+			continue
+		}
+		p.Held = false
+		p.Reason = ""
+		p.SendAt = sendAt.UnixMilli()
+		c.released[p.ID] = sendAt
+		slog.Info("approval turned off, releasing held message", "output", st.Name, "id", p.ID)
+	}
+}
+
+// applyRelease starts the countdown of a message released by
+// releaseApprovalHeld. Caller must hold c.mu.
+func (c *Controller) applyRelease(dm *delayedMsg) {
+	sendAt, ok := c.released[dm.id]
+	if !ok {
+		return
+	}
+	delete(c.released, dm.id)
+	dm.held = false
+	dm.sendAt = sendAt
+}
+
+// releaseHeld reports whether a held message was approved or skipped
+// and can leave the queue, consuming the approval.
+func (c *Controller) releaseHeld(msgID int64) (approved, skipped bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	approved = c.approveSet[msgID]
+	delete(c.approveSet, msgID)
+	return approved, c.skipSet[msgID]
+}
+
 // OutputStates returns the current state of all outputs in config order.
 func (c *Controller) OutputStates() []OutputState {
 	c.mu.RLock()
@@ -298,6 +744,8 @@ func (c *Controller) OutputStates() []OutputState {
 			}
 			cp.Recent = make([]string, len(s.Recent))
 			copy(cp.Recent, s.Recent)
+			cp.Failed = make([]FailedMsg, len(s.Failed))
+			copy(cp.Failed, s.Failed)
 			out = append(out, cp)
 		}
 	}
@@ -311,20 +759,42 @@ type delayedMsg struct {
 	sendAt time.Time
 	output string // output name
 	seqNum int    // seqCounter value for emoji
+	held   bool   // waiting for approval, sendAt not set
+	manual bool   // operator-injected, sent even while paused
+	chunks []string // already wrapped chunks (resent failures), nil = split text
+	src    msgSource
+
+	rec      *txRecord           // transcript record to report the outcome to, nil = none
+	delivery transcript.Delivery // outcome details collected while queued
+}
+
+// msgSource is the STT result a message was translated from, passed to
+// bots in bot.Message.
+type msgSource struct {
+	seq  int // Translation.Seq
+	text string
+	lang string
+	at   time.Time // when the translation reached the controller
 }
 
 func (c *Controller) run(ctx context.Context) {
 	defer c.wg.Done()
+	defer c.closeRecords()
+	defer c.stopDeliveries()
 
 	// Per-output ordered sender
+	type routed struct {
+		text string
+		rec  *txRecord
+	}
 	type outputSender struct {
 		nextSeq    int
 		seqCounter int
-		pending    map[int]string // seq → text to send
+		pending    map[int]routed // seq → text to send
 	}
 	senders := make(map[string]*outputSender)
 	for _, o := range c.outputs {
-		senders[o.Name] = &outputSender{pending: make(map[int]string)}
+		senders[o.Name] = &outputSender{pending: make(map[int]routed)}
 	}
 
 	// Delay queue: messages waiting to be sent
@@ -338,40 +808,36 @@ func (c *Controller) run(ctx context.Context) {
 		select {
 		case t, ok := <-c.ch:
 			if !ok {
-				// Channel closed — flush remaining
+				// Channel closed — flush remaining, including queued operator messages
+				for len(c.inject) > 0 {
+					delayQueue = append(delayQueue, <-c.inject)
+				}
 				c.flushDelayQueue(ctx, delayQueue)
 				return
 			}
-			// Log transcript once per STT result
+			received := time.Now()
+			// The transcript record is written once every output is done with it
+			var rec *txRecord
 			if c.tlog != nil && t.SourceText != "" {
-				logged := false
-				for _, o := range c.outputs {
-					if c.IsPaused(o.Name) {
-						continue
-					}
-					targetLang := o.TargetLang
-					if targetLang == "" {
-						targetLang = t.SourceLang
-					}
-					var text string
-					if o.TargetLang == "" {
-						text = t.SourceText
-					} else {
-						text = t.Texts[o.TargetLang]
-						if text == "" && isLangMatch(t.SourceLang, o.TargetLang) {
-							text = t.SourceText
-						}
-					}
-					if text != "" {
-						c.tlog.Write(t.SourceLang, t.SourceText, targetLang, text)
-						logged = true
-						break
-					}
+				at := t.At
+				if at.IsZero() {
+					at = received
 				}
-				// All paused or no translation available: log source text only
-				if !logged {
-					c.tlog.Write(t.SourceLang, t.SourceText, "", "")
+				outputs := make(map[string]*transcript.Delivery, len(c.outputs))
+				for _, o := range c.outputs {
+					outputs[o.Name] = &transcript.Delivery{TargetLang: o.TargetLang, Outcome: transcript.OutcomePending}
 				}
+				rec = newTxRecord(transcript.Record{
+					Type:         transcript.RecordUtterance,
+					Seq:          t.Seq,
+					Time:         at.UnixMilli(),
+					SourceLang:   t.SourceLang,
+					SourceText:   t.SourceText,
+					Confidence:   t.Confidence,
+					Translations: t.Results,
+					Outputs:      outputs,
+				})
+				c.addRecord(rec)
 			}
 
 			for _, o := range c.outputs {
@@ -379,7 +845,7 @@ func (c *Controller) run(ctx context.Context) {
 				if o.TargetLang == "" {
 					text = t.SourceText
 				} else {
-					text = t.Texts[o.TargetLang]
+					text = t.Texts[TextKey(o)]
 					if text == "" {
 						if isLangMatch(t.SourceLang, o.TargetLang) {
 							text = t.SourceText
@@ -387,20 +853,28 @@ func (c *Controller) run(ctx context.Context) {
 					}
 				}
 
-				// Buffer for ordered sending
+				// Buffer for ordered sending (outputs added after start begin at this seq)
 				s := senders[o.Name]
-				s.pending[t.Seq] = text
+				if s == nil {
+					s = &outputSender{nextSeq: t.Seq, pending: make(map[int]routed)}
+					senders[o.Name] = s
+				}
+				s.pending[t.Seq] = routed{text: text, rec: rec}
 
 				// Flush in order → push to delay queue
 				for {
-					txt, ok := s.pending[s.nextSeq]
+					r, ok := s.pending[s.nextSeq]
 					if !ok {
 						break
 					}
 					delete(s.pending, s.nextSeq)
 					s.nextSeq++
+					txt := r.text
+					delivery := transcript.Delivery{TargetLang: o.TargetLang, Text: txt}
 
 					if txt == "" {
+						delivery.Outcome = transcript.OutcomeNoText
+						c.deliver(r.rec, o.Name, delivery)
 						continue
 					}
 
@@ -410,40 +884,79 @@ func (c *Controller) run(ctx context.Context) {
 
 					if isPaused {
 						slog.Info("paused, dropping", "output", o.Name, "text", txt)
+						delivery.Outcome = transcript.OutcomePaused
+						c.deliver(r.rec, o.Name, delivery)
+						continue
+					}
+
+					txt, held, reason, keep := c.moderate(o.Name, txt)
+					delivery.Reason = reason
+					if !keep {
+						delivery.Outcome = transcript.OutcomeDropped
+						c.deliver(r.rec, o.Name, delivery)
 						continue
 					}
+					if !held && o.RequireApproval {
+						held, reason = true, ReasonApproval
+					}
 
 					// Assign message ID and push to delay queue
 					c.mu.Lock()
 					msgID := c.nextMsgID
 					c.nextMsgID++
-					sendAt := time.Now().Add(c.sendDelay)
+					var sendAt time.Time
+					if !held {
+						sendAt = time.Now().Add(SendDelay(o))
+					}
 					// Add to pending in output state for UI
 					if st, ok := c.outputStates[o.Name]; ok {
-						st.Pending = append(st.Pending, PendingMsg{
+						pm := PendingMsg{
 							ID:     msgID,
 							Text:   txt,
-							SendAt: sendAt.UnixMilli(),
-						})
+							Held:   held,
+							Reason: reason,
+						}
+						if !held {
+							pm.SendAt = sendAt.UnixMilli()
+						}
+						st.Pending = append(st.Pending, pm)
 						st.LastText = txt
 					}
 					c.mu.Unlock()
 
+					delivery.Text = txt
+					delivery.Held = held
 					delayQueue = append(delayQueue, delayedMsg{
-						id:     msgID,
-						text:   txt,
-						sendAt: sendAt,
-						output: o.Name,
-						seqNum: s.seqCounter,
+						id:       msgID,
+						text:     txt,
+						sendAt:   sendAt,
+						output:   o.Name,
+						seqNum:   s.seqCounter,
+						held:     held,
+						src:      msgSource{seq: t.Seq, text: t.SourceText, lang: t.SourceLang, at: received},
+						rec:      r.rec,
+						delivery: delivery,
 					})
 					s.seqCounter++
 					c.notifyChange()
 				}
 			}
 
+		case dm := <-c.inject:
+			// Operator messages take the output's next sequence emoji
+			if s := senders[dm.output]; s != nil && dm.chunks == nil {
+				dm.seqNum = s.seqCounter
+				s.seqCounter++
+			}
+			if dm.rec != nil {
+				c.addRecord(dm.rec)
+			}
+			delayQueue = append(delayQueue, dm)
+
 		case <-ticker.C:
 			// Send messages whose delay has expired
 			delayQueue = c.processDelayQueue(ctx, delayQueue)
+			c.flushStaleRecords()
 
 		case <-ctx.Done():
 			return
@@ -455,13 +968,25 @@ func (c *Controller) processDelayQueue(ctx context.Context, queue []delayedMsg)
 	now := time.Now()
 	remaining := queue[:0]
 	for _, dm := range queue {
-		if now.Before(dm.sendAt) {
+		c.mu.Lock()
+		c.applyRelease(&dm)
+		c.applyEdit(&dm)
+		c.mu.Unlock()
+
+		if dm.held {
+			// Held messages wait for an operator, however long it takes
+			if approved, skipped := c.releaseHeld(dm.id); !approved && !skipped {
+				remaining = append(remaining, dm)
+				continue
+			}
+		} else if now.Before(dm.sendAt) {
 			remaining = append(remaining, dm)
 			continue
 		}
 
 		// Check if skipped
 		c.mu.Lock()
+		c.applyEdit(&dm) // edited while being dequeued: send the new text now
 		skipped := c.skipSet[dm.id]
 		if skipped {
 			delete(c.skipSet, dm.id)
@@ -481,16 +1006,18 @@ func (c *Controller) processDelayQueue(ctx context.Context, queue []delayedMsg)
 
 		if skipped {
 			slog.Info("skipped by user", "output", dm.output, "text", dm.text)
+			c.resolve(dm, transcript.OutcomeSkipped, nil)
 			c.notifyChange()
 			continue
 		}
-		if isPaused {
+		if isPaused && !dm.manual {
 			slog.Info("paused at send time, dropping", "output", dm.output, "text", dm.text)
+			c.resolve(dm, transcript.OutcomePaused, nil)
 			c.notifyChange()
 			continue
 		}
 
-		c.sendMessage(ctx, dm)
+		c.dispatch(ctx, dm)
 		c.notifyChange()
 	}
 	return remaining
@@ -499,34 +1026,53 @@ func (c *Controller) processDelayQueue(ctx context.Context, queue []delayedMsg)
 func (c *Controller) flushDelayQueue(ctx context.Context, queue []delayedMsg) {
 	for _, dm := range queue {
 		c.mu.Lock()
+		c.applyRelease(&dm)
+		c.applyEdit(&dm)
 		skipped := c.skipSet[dm.id]
 		if skipped {
 			delete(c.skipSet, dm.id)
 		}
+		if dm.held && !c.approveSet[dm.id] {
+			skipped = true // never approved
+			dm.delivery.Reason = "not approved"
+			slog.Info("held message not approved, dropping", "output", dm.output, "text", dm.text)
+		}
+		delete(c.approveSet, dm.id)
 		c.mu.Unlock()
-		if !skipped {
-			c.sendMessage(ctx, dm)
+		if skipped {
+			c.resolve(dm, transcript.OutcomeSkipped, nil)
+		} else {
+			c.dispatch(ctx, dm)
 		}
 	}
 }
 
 func (c *Controller) sendMessage(ctx context.Context, dm delayedMsg) {
 	// Find output config
-	var o *config.OutputConfig
-	for i := range c.outputs {
-		if c.outputs[i].Name == dm.output {
-			o = &c.outputs[i]
-			break
-		}
+	c.mu.RLock()
+	var o config.OutputConfig
+	found := false
+	if oc := c.outputConfig(dm.output); oc != nil {
+		o, found = *oc, true
+	}
+	// Sink platforms send through their own bot; others round-robin the account pool
+	var sinkBot bot.Bot
+	if cur := c.sinks[dm.output]; cur != nil {
+		sinkBot = cur.bot
 	}
-	if o == nil {
+	c.mu.RUnlock()
+	if !found {
+		dm.delivery.Reason = "output removed"
+		c.resolve(dm, transcript.OutcomeDropped, nil)
 		return
 	}
 
-	// Pick bot via round-robin from account pool
 	accts := o.AccountPool()
-	if len(accts) == 0 {
+	if sinkBot != nil {
+		accts = nil
+	} else if len(accts) == 0 {
 		slog.Warn("no accounts for output", "output", dm.output)
+		c.recordFailed(dm, nil, fmt.Errorf("no accounts for output"))
 		return
 	}
 
@@ -535,38 +1081,47 @@ func (c *Controller) sendMessage(ctx context.Context, dm delayedMsg) {
 		targetRoom = c.streamerRoomID
 	}
 
-	prefix := o.Prefix
-	if o.ShowSeq {
-		prefix += seqEmojis[dm.seqNum%len(seqEmojis)]
-	}
+	chunks := dm.chunks
+	if chunks == nil {
+		prefix := o.Prefix
+		if o.ShowSeq {
+			prefix += seqEmojis[dm.seqNum%len(seqEmojis)]
+		}
 
-	// Use minimum maxLen across all pool bots so chunks fit any bot
-	minMax := 0
-	for _, name := range accts {
-		if pb := c.pool.Get(name); pb != nil {
-			if ml := pb.MaxMessageLen(); ml > 0 && (minMax <= 0 || ml < minMax) {
-				minMax = ml
+		// Use minimum maxLen across all pool bots so chunks fit any bot
+		minMax := 0
+		if sinkBot != nil {
+			minMax = sinkBot.MaxMessageLen()
+		}
+		for _, name := range accts {
+			if pb := c.pool.Get(name); pb != nil && pb.Platform() == o.Platform {
+				if ml := pb.MaxMessageLen(); ml > 0 && (minMax <= 0 || ml < minMax) {
+					minMax = ml
+				}
 			}
 		}
+		chunks = splitWithWrap(dm.text, prefix, o.Suffix, minMax)
 	}
 
-	chunks := splitWithWrap(dm.text, prefix, o.Suffix, minMax)
-	for _, chunk := range chunks {
-		// Round-robin: pick next bot for each chunk
-		c.mu.Lock()
-		idx := c.rrIndex[dm.output] % len(accts)
-		c.rrIndex[dm.output] = (idx + 1) % len(accts)
-		c.mu.Unlock()
-
-		b := c.pool.Get(accts[idx])
-		if b == nil {
-			slog.Warn("bot not found", "output", dm.output, "bot", accts[idx])
-			continue
-		}
-		slog.Info("sending", "output", dm.output, "bot", b.Name(), "room", targetRoom, "text", chunk)
-		if err := b.Send(ctx, targetRoom, chunk); err != nil {
-			slog.Error("send failed", "output", dm.output, "bot", b.Name(), "err", err)
-			break
+	msg := bot.Message{
+		Streamer:   c.streamerName,
+		Output:     dm.output,
+		Channel:    o.Channel,
+		ID:         dm.id,
+		Seq:        dm.src.seq,
+		SourceText: dm.src.text,
+		SourceLang: dm.src.lang,
+		TargetLang: o.TargetLang,
+		Text:       dm.text,
+		Manual:     dm.manual,
+		ReceivedAt: dm.src.at,
+		Chunks:     len(chunks),
+	}
+	for i, chunk := range chunks {
+		msg.Chunk = i
+		if err := c.sendChunk(bot.WithMessage(ctx, msg), dm.output, o.Platform, sinkBot, accts, targetRoom, chunk); err != nil {
+			c.recordFailed(dm, chunks[i:], err)
+			return
 		}
 	}
 
@@ -579,6 +1134,197 @@ func (c *Controller) sendMessage(ctx context.Context, dm delayedMsg) {
 		}
 	}
 	c.mu.Unlock()
+	c.resolve(dm, transcript.OutcomeSent, nil)
+}
+
+// Send retry policy: transient errors retry the same bot with backoff;
+// account-specific errors (rate limit, mute, expired login) move on to the
+// next account of the pool.
+const (
+	maxSendRetries   = 2
+	sendRetryBackoff = 500 * time.Millisecond
+)
+
+// sendChunk delivers one chunk, retrying and failing over between accounts.
+// Accounts are taken round-robin starting at the output's rotation index.
+func (c *Controller) sendChunk(ctx context.Context, output, platform string, sinkBot bot.Bot, accts []string, room int64, chunk string) error {
+	failed := make(map[string]bool) // accounts that failed for account-specific reasons
+	backoff := sendRetryBackoff
+	retries := 0
+	var lastErr error
+	for {
+		b := sinkBot
+		if b == nil {
+			b = c.nextBot(output, platform, room, accts, failed)
+			if b == nil {
+				if lastErr == nil {
+					lastErr = fmt.Errorf("no usable account (pool: %v)", accts)
+				}
+				return lastErr
+			}
+		}
+
+		slog.Info("sending", "output", output, "bot", b.Name(), "room", room, "text", chunk)
+		err := b.Send(ctx, room, chunk)
+		if sinkBot == nil {
+			c.pool.RecordSend(b.Name(), room, err)
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		kind := bot.Classify(err)
+		slog.Error("send failed", "output", output, "bot", b.Name(), "kind", kind, "err", err)
+
+		switch {
+		case kind == bot.KindCanceled || kind == bot.KindContentRejected:
+			return err
+		case kind.AccountSpecific() && sinkBot == nil && len(failed)+1 < len(accts):
+			failed[b.Name()] = true
+			slog.Info("failing over to next account", "output", output, "from", b.Name())
+			continue
+		case kind == bot.KindMuted || kind == bot.KindAuthExpired:
+			return err // no account left to fail over to
+		case retries >= maxSendRetries || bot.Retried(err):
+			return err
+		}
+		retries++
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// nextBot returns the next pool bot for an output in round-robin order,
+// skipping accounts in exclude, quarantined accounts or accounts muted in
+// room, accounts missing from the pool and bots of another platform than
+// the output's.
+// Rate-limited accounts are only used if no other is left.
+func (c *Controller) nextBot(output, platform string, room int64, accts []string, exclude map[string]bool) bot.Bot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ignoreCooldown := range []bool{false, true} {
+		for range accts {
+			idx := c.rrIndex[output] % len(accts)
+			c.rrIndex[output] = (idx + 1) % len(accts)
+			name := accts[idx]
+			if exclude[name] || !c.pool.Usable(name, room, ignoreCooldown) {
+				continue
+			}
+			b := c.pool.Get(name)
+			if b != nil && b.Platform() == platform {
+				return b
+			}
+			if ignoreCooldown {
+				continue
+			}
+			if b == nil {
+				slog.Warn("bot not found", "output", output, "bot", name)
+			} else {
+				slog.Warn("bot platform does not match output", "output", output, "bot", name, "platform", b.Platform())
+			}
+		}
+	}
+	return nil
+}
+
+// maxFailed bounds the failed-message list of an output.
+const maxFailed = 20
+
+// recordFailed adds a message whose chunks could not all be sent to the
+// output's failed list. remaining are the unsent, already wrapped chunks
+// (nil = resend the whole text).
+func (c *Controller) recordFailed(dm delayedMsg, remaining []string, err error) {
+	c.resolve(dm, transcript.OutcomeFailed, err)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st, ok := c.outputStates[dm.output]
+	if !ok {
+		return
+	}
+	st.Failed = append(st.Failed, FailedMsg{
+		ID:     dm.id,
+		Text:   dm.text,
+		Kind:   bot.Classify(err).String(),
+		Reason: bot.Reason(err),
+		Error:  err.Error(),
+		At:     time.Now().UnixMilli(),
+		chunks: remaining,
+		src:    dm.src,
+	})
+	if len(st.Failed) > maxFailed {
+		st.Failed = st.Failed[len(st.Failed)-maxFailed:]
+	}
+}
+
+// ResendFailed queues a failed message again, without delay. Only the
+// chunks that were not delivered are sent. Returns false if msgID is not
+// in the output's failed list.
+func (c *Controller) ResendFailed(msgID int64) bool {
+	c.mu.Lock()
+	for _, st := range c.outputStates {
+		for i, f := range st.Failed {
+			if f.ID != msgID {
+				continue
+			}
+			now := time.Now()
+			dm := delayedMsg{
+				id:       c.nextMsgID,
+				text:     f.Text,
+				sendAt:   now,
+				output:   st.Name,
+				manual:   true,
+				chunks:   f.chunks,
+				src:      f.src,
+				delivery: transcript.Delivery{TargetLang: st.TargetLang, Text: f.Text},
+			}
+			if c.tlog != nil {
+				seq := f.src.seq
+				if f.src.text == "" {
+					seq = -1 // operator message
+				}
+				dm.rec = newTxRecord(transcript.Record{
+					Type:    transcript.RecordDelivery,
+					Seq:     seq,
+					Time:    now.UnixMilli(),
+					Outputs: map[string]*transcript.Delivery{st.Name: {TargetLang: st.TargetLang, Outcome: transcript.OutcomePending}},
+				})
+			}
+			select {
+			case c.inject <- dm:
+			default:
+				c.mu.Unlock()
+				return false
+			}
+			c.nextMsgID++
+			st.Failed = append(st.Failed[:i], st.Failed[i+1:]...)
+			st.Pending = append(st.Pending, PendingMsg{ID: dm.id, Text: f.Text, SendAt: now.UnixMilli()})
+			c.mu.Unlock()
+			slog.Info("resending failed message", "output", st.Name, "text", f.Text)
+			c.notifyChange()
+			return true
+		}
+	}
+	c.mu.Unlock()
+	return false
+}
+
+// DismissFailed removes a message from the failed list.
+func (c *Controller) DismissFailed(msgID int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, st := range c.outputStates {
+		for i, f := range st.Failed {
+			if f.ID == msgID {
+				st.Failed = append(st.Failed[:i], st.Failed[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // splitWithWrap splits text into chunks where each chunk is wrapped with prefix+suffix
@@ -638,42 +1384,186 @@ func isLangMatch(detected, target string) bool {
 	return false
 }
 
+// TextKey returns the Translation.Texts key for an output: its target
+// language, qualified by backend name when the output overrides the
+// streamer's translator (so two outputs with the same language but
+// different engines don't collide).
+func TextKey(o config.OutputConfig) string {
+	if o.Translator == "" {
+		return o.TargetLang
+	}
+	return o.Translator + "/" + o.TargetLang
+}
+
+// TranslateOptions holds a streamer's translation settings.
+type TranslateOptions struct {
+	Translators *translate.Registry
+	Translator  string             // streamer's backend name ("" = default)
+	History     *translate.History // rolling prompt context (nil = none)
+	Glossary    translate.Glossary // streamer's terminology, enforced after translation
+	Batch       bool               // one request for all target languages where the backend supports it
+}
+
+// Utterance is an STT final handed to TranslateAndSubmit.
+type Utterance struct {
+	Seq        int
+	Text       string
+	Lang       string
+	Confidence float32
+	At         time.Time // when STT returned the final
+}
+
 // TranslateAndSubmit handles the translation fan-out for a single STT result.
-func TranslateAndSubmit(ctx context.Context, ctrl *Controller, translator *translate.GeminiTranslator, seq int, sourceText, sourceLang string, outputs []config.OutputConfig) {
-	needed := make(map[string]bool)
+// Outputs may override opts.Translator with their own backend; each request
+// carries opts.History context for its target language. With opts.Batch,
+// a backend serving several languages is asked once for all of them and
+// languages missing from the batched answer fall back to single requests.
+func TranslateAndSubmit(ctx context.Context, ctrl *Controller, opts TranslateOptions, u Utterance, outputs []config.OutputConfig) {
+	seq, sourceText, sourceLang := u.Seq, u.Text, u.Lang
+	type job struct {
+		backend    string
+		targetLang string
+	}
+	needed := make(map[string]job) // text key → job
 	for _, o := range outputs {
 		if o.TargetLang != "" && !isLangMatch(sourceLang, o.TargetLang) {
-			needed[o.TargetLang] = true
+			backend := o.Translator
+			if backend == "" {
+				backend = opts.Translator
+			}
+			needed[TextKey(o)] = job{backend: backend, targetLang: o.TargetLang}
 		}
 	}
 
 	texts := make(map[string]string)
+	var results []transcript.Translation
 
 	if len(needed) == 0 {
 		ctrl.Submit(Translation{
 			Seq:        seq,
 			SourceText: sourceText,
 			SourceLang: sourceLang,
+			Confidence: u.Confidence,
+			At:         u.At,
 			Texts:      texts,
 		})
 		return
 	}
 
 	var mu sync.Mutex
+	// result records an attempt for the transcript; report is the ctx
+	// report of the request that produced it
+	result := func(j job, report *translate.ModelReport, text string, err error) {
+		r := transcript.Translation{
+			TargetLang: j.targetLang,
+			Translator: opts.Translators.Name(j.backend),
+			Model:      report.Model(j.targetLang),
+			Text:       text,
+		}
+		if r.Model == "" {
+			r.Model = opts.Translators.Model(j.backend)
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		mu.Lock()
+		results = append(results, r)
+		mu.Unlock()
+	}
+	// store keeps a translation after glossary enforcement and returns it
+	store := func(key string, j job, terms []translate.Term, translated string) string {
+		if translated != "" {
+			var forced []translate.Term
+			if translated, forced = translate.Enforce(terms, translated); len(forced) > 0 {
+				slog.Info("glossary enforced", "lang", j.targetLang, "terms", len(forced), "result", translated)
+			}
+		}
+		opts.History.SetTranslation(seq, j.targetLang, translated)
+		mu.Lock()
+		texts[key] = translated
+		mu.Unlock()
+		return translated
+	}
+	translateOne := func(key string, j job) {
+		terms := opts.Glossary.Match(sourceText, j.targetLang)
+		ctx, report := translate.WithModelReport(ctx)
+		translated, err := opts.Translators.Get(j.backend).Translate(ctx, translate.Request{
+			Text:       sourceText,
+			SourceLang: sourceLang,
+			TargetLang: j.targetLang,
+			Context:    opts.History.Before(seq, j.targetLang),
+			Glossary:   terms,
+		})
+		if err != nil {
+			slog.Error("translate error", "lang", j.targetLang, "backend", j.backend, "err", err)
+			result(j, report, "", err)
+			return
+		}
+		result(j, report, store(key, j, terms, translated), nil)
+	}
+
 	var wg sync.WaitGroup
-	for lang := range needed {
+	single := make(map[string]job)
+	if opts.Batch {
+		byBackend := make(map[string][]string) // backend → text keys
+		for key, j := range needed {
+			byBackend[j.backend] = append(byBackend[j.backend], key)
+		}
+		for backend, keys := range byBackend {
+			mt, ok := opts.Translators.Get(backend).(translate.MultiTranslator)
+			if !ok || len(keys) < 2 {
+				for _, key := range keys {
+					single[key] = needed[key]
+				}
+				continue
+			}
+			wg.Add(1)
+			go func(backend string, keys []string) {
+				defer wg.Done()
+				req := translate.MultiRequest{
+					Text:       sourceText,
+					SourceLang: sourceLang,
+					Context:    opts.History.Before(seq, ""),
+					Glossary:   make(map[string][]translate.Term),
+				}
+				for _, key := range keys {
+					lang := needed[key].targetLang
+					if _, dup := req.Glossary[lang]; !dup {
+						req.TargetLangs = append(req.TargetLangs, lang)
+						req.Glossary[lang] = opts.Glossary.Match(sourceText, lang)
+					}
+				}
+				ctx, report := translate.WithModelReport(ctx)
+				batch, err := mt.TranslateMulti(ctx, req)
+				if err != nil {
+					slog.Warn("batched translate failed, falling back to per-language", "backend", backend, "err", err)
+				}
+				var fallback sync.WaitGroup
+				for _, key := range keys {
+					j := needed[key]
+					if translated, ok := batch[j.targetLang]; ok {
+						result(j, report, store(key, j, req.Glossary[j.targetLang], translated), nil)
+						continue
+					}
+					fallback.Add(1)
+					go func(key string, j job) {
+						defer fallback.Done()
+						translateOne(key, j)
+					}(key, j)
+				}
+				fallback.Wait()
+			}(backend, keys)
+		}
+	} else {
+		single = needed
+	}
+
+	for key, j := range single {
 		wg.Add(1)
-		go func(targetLang string) {
+		go func(key string, j job) {
 			defer wg.Done()
-			translated, err := translator.Translate(ctx, sourceText, sourceLang, targetLang)
-			if err != nil {
-				slog.Error("translate error", "lang", targetLang, "err", err)
-				return
-			}
-			mu.Lock()
-			texts[targetLang] = translated
-			mu.Unlock()
-		}(lang)
+			translateOne(key, j)
+		}(key, j)
 	}
 	wg.Wait()
 
@@ -681,6 +1571,9 @@ func TranslateAndSubmit(ctx context.Context, ctrl *Controller, translator *trans
 		Seq:        seq,
 		SourceText: sourceText,
 		SourceLang: sourceLang,
+		Confidence: u.Confidence,
+		At:         u.At,
 		Texts:      texts,
+		Results:    results,
 	})
 }
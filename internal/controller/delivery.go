@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"sync"
+)
+
+// outputDelivery sends an output's due messages in order on its own
+// goroutine, so a slow platform (webhook retries, the Twitch rate window)
+// holds up only that output, not the run loop and the other outputs.
+type outputDelivery struct {
+	mu     sync.Mutex
+	queue  []delayedMsg
+	closed bool          // no more messages; exit once the queue is empty
+	wake   chan struct{} // signalled when queue or closed changes
+}
+
+// dispatch hands a due message to its output's delivery goroutine,
+// starting it on first use. Run goroutine only.
+func (c *Controller) dispatch(ctx context.Context, dm delayedMsg) {
+	d := c.deliveries[dm.output]
+	if d == nil {
+		d = &outputDelivery{wake: make(chan struct{}, 1)}
+		c.deliveries[dm.output] = d
+		c.deliveryWG.Add(1)
+		go c.runDelivery(ctx, d)
+	}
+	d.mu.Lock()
+	d.queue = append(d.queue, dm)
+	d.mu.Unlock()
+	d.signal()
+}
+
+func (d *outputDelivery) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default: // already pending
+	}
+}
+
+func (c *Controller) runDelivery(ctx context.Context, d *outputDelivery) {
+	defer c.deliveryWG.Done()
+	for ctx.Err() == nil {
+		d.mu.Lock()
+		if len(d.queue) == 0 {
+			closed := d.closed
+			d.mu.Unlock()
+			if closed {
+				return
+			}
+			select {
+			case <-d.wake:
+			case <-ctx.Done():
+			}
+			continue
+		}
+		dm := d.queue[0]
+		d.queue = d.queue[1:]
+		d.mu.Unlock()
+
+		c.sendMessage(ctx, dm)
+		c.notifyChange()
+	}
+}
+
+// stopDeliveries lets every delivery goroutine send what it has queued
+// and waits for them. Messages left when ctx is canceled stay unresolved.
+// Run goroutine only.
+func (c *Controller) stopDeliveries() {
+	for _, d := range c.deliveries {
+		d.mu.Lock()
+		d.closed = true
+		d.mu.Unlock()
+		d.signal()
+	}
+	c.deliveryWG.Wait()
+}
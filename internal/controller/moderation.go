@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/christian-lee/livesub/internal/config"
+)
+
+// Moderation actions for messages matching an output's blocklist.
+const (
+	ModerationDrop = "drop" // discard the message
+	ModerationMask = "mask" // replace matches with '*' and send
+	ModerationHold = "hold" // keep it pending until an operator approves or skips it
+)
+
+// moderator matches messages against an output's blocklists.
+type moderator struct {
+	action   string
+	patterns []*regexp.Regexp // word lists compiled into one pattern, then the configured regexes
+}
+
+// ValidateModeration reports config errors (bad regex, unreadable list,
+// unknown action) so they can be rejected when an output is saved.
+func ValidateModeration(cfg config.ModerationConfig) error {
+	_, err := newModerator(cfg)
+	return err
+}
+
+// newModerator compiles cfg. Returns nil if nothing is configured.
+func newModerator(cfg config.ModerationConfig) (*moderator, error) {
+	action := cfg.Action
+	switch action {
+	case "":
+		action = ModerationHold
+	case ModerationDrop, ModerationMask, ModerationHold:
+	default:
+		return nil, fmt.Errorf("unknown moderation action %q", cfg.Action)
+	}
+
+	words := append([]string(nil), cfg.Words...)
+	for _, path := range cfg.Lists {
+		list, err := readWordList(path)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, list...)
+	}
+
+	m := &moderator{action: action}
+	if re := wordsPattern(words); re != nil {
+		m.patterns = append(m.patterns, re)
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("moderation pattern %q: %w", p, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	if len(m.patterns) == 0 {
+		return nil, nil
+	}
+	return m, nil
+}
+
+// wordsPattern compiles words into one case-insensitive alternation,
+// longest first so overlapping words mask completely.
+func wordsPattern(words []string) *regexp.Regexp {
+	seen := make(map[string]bool)
+	var quoted []string
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" || seen[strings.ToLower(w)] {
+			continue
+		}
+		seen[strings.ToLower(w)] = true
+		quoted = append(quoted, regexp.QuoteMeta(w))
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(quoted, "|") + `)`)
+}
+
+// readWordList reads one word per line; blank lines and lines starting
+// with '#' are ignored.
+func readWordList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("moderation list: %w", err)
+	}
+	defer f.Close()
+
+	var words []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("moderation list %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// check returns the distinct blocked fragments found in text.
+func (m *moderator) check(text string) []string {
+	seen := make(map[string]bool)
+	var matched []string
+	for _, re := range m.patterns {
+		for _, s := range re.FindAllString(text, -1) {
+			if s != "" && !seen[s] {
+				seen[s] = true
+				matched = append(matched, s)
+			}
+		}
+	}
+	return matched
+}
+
+// mask replaces every blocked fragment with '*' of the same length.
+func (m *moderator) mask(text string) string {
+	for _, re := range m.patterns {
+		text = re.ReplaceAllStringFunc(text, func(s string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(s))
+		})
+	}
+	return text
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/christian-lee/livesub/internal/bot"
+	"github.com/christian-lee/livesub/internal/transcript"
+)
+
+// maxRecordOpen is how long a transcript record waits for its outputs
+// (e.g. a message held for approval) before it is written with the
+// missing outcomes as pending. Later outcomes follow as delivery records.
+const maxRecordOpen = 10 * time.Minute
+
+// txRecord is a transcript record collecting per-output outcomes.
+// Guarded by Controller.recMu once queued.
+type txRecord struct {
+	transcript.Record
+	open    int // outputs without an outcome
+	created time.Time
+	written bool
+}
+
+// newTxRecord creates a record waiting for one outcome per entry of
+// r.Outputs.
+func newTxRecord(r transcript.Record) *txRecord {
+	return &txRecord{Record: r, open: len(r.Outputs), created: time.Now()}
+}
+
+// resolve reports the final outcome of a queued message.
+func (c *Controller) resolve(dm delayedMsg, outcome string, err error) {
+	d := dm.delivery
+	d.Outcome = outcome
+	d.Text = dm.text
+	if err != nil {
+		d.Reason = bot.Classify(err).String()
+		d.Error = err.Error()
+	}
+	c.deliver(dm.rec, dm.output, d)
+}
+
+// addRecord queues rec for outcomes, writing it now if it has no outputs.
+func (c *Controller) addRecord(rec *txRecord) {
+	c.recMu.Lock()
+	defer c.recMu.Unlock()
+	c.records[rec] = true
+	if rec.open <= 0 {
+		c.writeRecord(rec)
+	}
+}
+
+// deliver records an output's outcome and writes the record once every
+// output has one. Called from the run and delivery goroutines.
+func (c *Controller) deliver(rec *txRecord, output string, d transcript.Delivery) {
+	if rec == nil {
+		return
+	}
+	c.recMu.Lock()
+	defer c.recMu.Unlock()
+	d.At = time.Now().UnixMilli()
+	if rec.written {
+		c.tlog.WriteRecord(transcript.Record{
+			Type:    transcript.RecordDelivery,
+			Seq:     rec.Seq,
+			Outputs: map[string]*transcript.Delivery{output: &d},
+		})
+		return
+	}
+	if cur, ok := rec.Outputs[output]; ok && cur.Outcome == transcript.OutcomePending {
+		rec.open--
+	}
+	rec.Outputs[output] = &d
+	if rec.open <= 0 {
+		c.writeRecord(rec)
+	}
+}
+
+// writeRecord writes rec. Caller must hold c.recMu.
+func (c *Controller) writeRecord(rec *txRecord) {
+	delete(c.records, rec)
+	rec.written = true
+	c.tlog.WriteRecord(rec.Record)
+}
+
+// flushStaleRecords writes records that waited longer than maxRecordOpen.
+func (c *Controller) flushStaleRecords() {
+	c.recMu.Lock()
+	defer c.recMu.Unlock()
+	for rec := range c.records {
+		if time.Since(rec.created) > maxRecordOpen {
+			c.writeRecord(rec)
+		}
+	}
+}
+
+// closeRecords writes every open record when the controller stops.
+func (c *Controller) closeRecords() {
+	c.recMu.Lock()
+	defer c.recMu.Unlock()
+	for rec := range c.records {
+		c.writeRecord(rec)
+	}
+}
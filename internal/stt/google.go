@@ -10,11 +10,23 @@ import (
 	speechpb "cloud.google.com/go/speech/apiv1/speechpb"
 )
 
+func init() {
+	Register("google", func(ctx context.Context, opts Options) (Recognizer, error) {
+		s, err := NewGoogleSTT(ctx, opts.Language, opts.AltLangs)
+		if err != nil {
+			return nil, err
+		}
+		s.opts = opts
+		return s, nil
+	})
+}
+
 // GoogleSTT performs streaming speech-to-text using Google Cloud Speech API.
 type GoogleSTT struct {
 	client   *speech.Client
 	language string   // primary language
 	altLangs []string // additional languages for auto-detection
+	opts     Options  // recognition tuning (phrase hints, model, ...)
 }
 
 func NewGoogleSTT(ctx context.Context, language string, altLangs []string) (*GoogleSTT, error) {
@@ -30,12 +42,27 @@ func NewGoogleSTT(ctx context.Context, language string, altLangs []string) (*Goo
 	}, nil
 }
 
-// StreamResult represents a transcription result.
-type StreamResult struct {
-	Text       string
-	IsFinal    bool
-	Language   string  // detected language code (e.g. "ja-jp", "en-us", "zh-cn")
-	Confidence float32 // 0.0-1.0, from STT engine
+// recognitionConfig builds the session config from the language settings
+// and the streamer's tuning options.
+func (s *GoogleSTT) recognitionConfig() *speechpb.RecognitionConfig {
+	cfg := &speechpb.RecognitionConfig{
+		Encoding:                   speechpb.RecognitionConfig_LINEAR16,
+		SampleRateHertz:            16000,
+		LanguageCode:               s.language,
+		AlternativeLanguageCodes:   s.altLangs,
+		EnableAutomaticPunctuation: true,
+		Model:                      s.opts.Model,
+		UseEnhanced:                s.opts.Enhanced,
+		ProfanityFilter:            s.opts.ProfanityFilter,
+		EnableWordTimeOffsets:      s.opts.WordTimeOffsets,
+	}
+	if len(s.opts.Phrases) > 0 {
+		cfg.SpeechContexts = []*speechpb.SpeechContext{{
+			Phrases: s.opts.Phrases,
+			Boost:   s.opts.PhraseBoost,
+		}}
+	}
+	return cfg
 }
 
 // Stream starts a streaming recognition session.
@@ -51,13 +78,7 @@ func (s *GoogleSTT) Stream(ctx context.Context, audioReader io.Reader, results c
 	if err := stream.Send(&speechpb.StreamingRecognizeRequest{
 		StreamingRequest: &speechpb.StreamingRecognizeRequest_StreamingConfig{
 			StreamingConfig: &speechpb.StreamingRecognitionConfig{
-				Config: &speechpb.RecognitionConfig{
-					Encoding:                   speechpb.RecognitionConfig_LINEAR16,
-					SampleRateHertz:            16000,
-					LanguageCode:               s.language,
-					AlternativeLanguageCodes:   s.altLangs,
-					EnableAutomaticPunctuation: true,
-				},
+				Config:         s.recognitionConfig(),
 				InterimResults: true,
 			},
 		},
@@ -109,6 +130,13 @@ func (s *GoogleSTT) Stream(ctx context.Context, audioReader io.Reader, results c
 					Language:   result.GetLanguageCode(),
 					Confidence: alt.Confidence,
 				}
+				for _, w := range alt.Words {
+					sr.Words = append(sr.Words, Word{
+						Text:  w.Word,
+						Start: w.StartTime.AsDuration(),
+						End:   w.EndTime.AsDuration(),
+					})
+				}
 
 				if sr.IsFinal {
 					slog.Info("STT final", "text", sr.Text, "lang", sr.Language, "confidence", alt.Confidence)
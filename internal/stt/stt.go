@@ -0,0 +1,91 @@
+package stt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Recognizer is a streaming speech-to-text backend.
+type Recognizer interface {
+	// Stream reads PCM s16le 16kHz mono from audioReader and sends results
+	// until the audio ends (returns nil) or the session fails (returns error).
+	Stream(ctx context.Context, audioReader io.Reader, results chan<- StreamResult) error
+	// Close releases the backend's resources.
+	Close() error
+}
+
+// StreamResult represents a transcription result.
+type StreamResult struct {
+	Text       string
+	IsFinal    bool
+	Language   string  // detected language code (e.g. "ja-jp", "en-us", "zh-cn")
+	Confidence float32 // 0.0-1.0, from STT engine
+	Words      []Word  // per-word timing, only when Options.WordTimeOffsets is set
+}
+
+// Word is one recognized word with offsets from the start of the session.
+type Word struct {
+	Text  string
+	Start time.Duration
+	End   time.Duration
+}
+
+// Options configures a Recognizer for one streaming session.
+type Options struct {
+	Language string   // primary language
+	AltLangs []string // additional languages for auto-detection
+	Endpoint string   // server URL for self-hosted backends (e.g. whisper)
+
+	// Recognition tuning; zero values keep the backend's defaults.
+	Phrases         []string // phrase hints (names the model should prefer)
+	PhraseBoost     float32  // weight of the phrase hints
+	Model           string   // recognition model (e.g. "latest_long")
+	Enhanced        bool     // use the enhanced model variant
+	ProfanityFilter bool     // mask profanity
+	WordTimeOffsets bool     // fill StreamResult.Words
+}
+
+// Factory creates a Recognizer from options.
+type Factory func(ctx context.Context, opts Options) (Recognizer, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]Factory)
+)
+
+// Register makes a backend available under the given provider name.
+func Register(provider string, f Factory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[provider] = f
+}
+
+// Providers returns the registered provider names, sorted.
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	out := make([]string, 0, len(providers))
+	for name := range providers {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// New creates a Recognizer for the given provider ("" = google).
+func New(ctx context.Context, provider string, opts Options) (Recognizer, error) {
+	if provider == "" {
+		provider = "google"
+	}
+	providersMu.RLock()
+	f, ok := providers[provider]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown stt provider %q (available: %v)", provider, Providers())
+	}
+	return f(ctx, opts)
+}
@@ -0,0 +1,206 @@
+package stt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func init() {
+	Register("whisper", func(ctx context.Context, opts Options) (Recognizer, error) {
+		s, err := NewWhisperSTT(opts.Endpoint, opts.Language, opts.AltLangs)
+		if err != nil {
+			return nil, err
+		}
+		s.opts = opts
+		return s, nil
+	})
+}
+
+// WhisperSTT streams audio to a self-hosted Whisper / faster-whisper server
+// over WebSocket.
+//
+// Protocol:
+//   - client sends one JSON text frame with the session config
+//     ({"language","alt_languages","sample_rate","encoding"}, optionally
+//     "initial_prompt" built from the phrase hints, "model" and
+//     "word_timestamps")
+//   - client sends PCM s16le 16kHz mono as binary frames
+//   - client sends {"type":"eof"} when the audio ends
+//   - server replies with JSON text frames
+//     ({"text","is_final","language","confidence"}, optionally "words":
+//     [{"word","start","end"}] in seconds) and closes the
+//     connection after flushing the last result
+type WhisperSTT struct {
+	endpoint string
+	language string
+	altLangs []string
+	opts     Options
+}
+
+// NewWhisperSTT creates a Whisper backend. http(s) endpoints are
+// rewritten to ws(s).
+func NewWhisperSTT(endpoint, language string, altLangs []string) (*WhisperSTT, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("whisper stt: endpoint not configured")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("whisper stt: parse endpoint: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	case "ws", "wss":
+	default:
+		return nil, fmt.Errorf("whisper stt: unsupported endpoint scheme %q", u.Scheme)
+	}
+	return &WhisperSTT{
+		endpoint: u.String(),
+		language: language,
+		altLangs: altLangs,
+	}, nil
+}
+
+type whisperConfig struct {
+	Language     string   `json:"language"`
+	AltLanguages []string `json:"alt_languages,omitempty"`
+	SampleRate   int      `json:"sample_rate"`
+	Encoding     string   `json:"encoding"`
+
+	InitialPrompt  string `json:"initial_prompt,omitempty"` // biases decoding toward the phrase hints
+	Model          string `json:"model,omitempty"`
+	WordTimestamps bool   `json:"word_timestamps,omitempty"`
+}
+
+type whisperResult struct {
+	Text       string  `json:"text"`
+	IsFinal    bool    `json:"is_final"`
+	Language   string  `json:"language"`
+	Confidence float32 `json:"confidence"`
+	Words      []struct {
+		Word  string  `json:"word"`
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+	} `json:"words"`
+	Error string `json:"error"`
+}
+
+// Stream starts a streaming recognition session.
+// Reads PCM s16le 16kHz mono from audioReader.
+// Returns nil once the audio reader hits EOF and the server has flushed.
+func (s *WhisperSTT) Stream(ctx context.Context, audioReader io.Reader, results chan<- StreamResult) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("dial whisper: %w", err)
+	}
+	defer conn.Close()
+
+	// Unblock ReadMessage when ctx is cancelled
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	if err := conn.WriteJSON(whisperConfig{
+		Language:     s.language,
+		AltLanguages: s.altLangs,
+		SampleRate:   16000,
+		Encoding:     "s16le",
+
+		InitialPrompt:  strings.Join(s.opts.Phrases, ", "),
+		Model:          s.opts.Model,
+		WordTimestamps: s.opts.WordTimeOffsets,
+	}); err != nil {
+		return fmt.Errorf("send config: %w", err)
+	}
+
+	// Goroutine: feed audio data
+	var audioEOF atomic.Bool
+	go func() {
+		buf := make([]byte, 3200) // 100ms of 16kHz 16-bit mono
+		for {
+			n, err := audioReader.Read(buf)
+			if n > 0 {
+				if werr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					slog.Error("send audio error", "err", werr)
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					slog.Error("audio read error", "err", err)
+				}
+				audioEOF.Store(true)
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"eof"}`))
+				return
+			}
+		}
+	}()
+
+	// Receive results
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var ce *websocket.CloseError
+			if audioEOF.Load() && (errors.As(err, &ce) || errors.Is(err, io.EOF)) {
+				return nil
+			}
+			return fmt.Errorf("recv: %w", err)
+		}
+
+		var r whisperResult
+		if err := json.Unmarshal(data, &r); err != nil {
+			slog.Warn("whisper: bad message", "err", err, "data", string(data))
+			continue
+		}
+		if r.Error != "" {
+			return fmt.Errorf("whisper server: %s", r.Error)
+		}
+		text := strings.TrimSpace(r.Text)
+		if text == "" {
+			continue
+		}
+		sr := StreamResult{
+			Text:       text,
+			IsFinal:    r.IsFinal,
+			Language:   strings.ToLower(r.Language),
+			Confidence: r.Confidence,
+		}
+		for _, w := range r.Words {
+			sr.Words = append(sr.Words, Word{
+				Text:  strings.TrimSpace(w.Word),
+				Start: time.Duration(w.Start * float64(time.Second)),
+				End:   time.Duration(w.End * float64(time.Second)),
+			})
+		}
+		if sr.IsFinal {
+			slog.Info("STT final", "text", sr.Text, "lang", sr.Language, "confidence", sr.Confidence)
+		}
+		results <- sr
+	}
+}
+
+// Close is a no-op; each Stream call owns its own connection.
+func (s *WhisperSTT) Close() error {
+	return nil
+}
@@ -2,22 +2,25 @@ package transcript
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
-// Logger writes timestamped multi-language translation pairs to CSV files.
-// One file per stream per session (live start → live end).
+// Logger writes a stream session (live start → live end) as a JSONL file
+// of Records plus a CSV view of them with one row per language pair.
 type Logger struct {
 	mu        sync.Mutex
 	dir       string
 	file      *os.File
 	writer    *csv.Writer
+	jsonl     *os.File
 	roomID    int64
 	name      string
 	session   string // timestamp-based session ID
@@ -27,7 +30,7 @@ type Logger struct {
 // NewLogger creates a transcript logger for a stream session.
 // If a recent file for the same room exists (modified within 1 hour),
 // it resumes appending to that file instead of creating a new one.
-// Files are saved as: <dir>/<room_id>_<name>_<date>_<time>.csv
+// Files are saved as: <dir>/<room_id>_<name>_<date>_<time>.csv and .jsonl
 func NewLogger(dir string, roomID int64, name string) (*Logger, error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("create transcript dir: %w", err)
@@ -60,17 +63,24 @@ func NewLogger(dir string, roomID int64, name string) (*Logger, error) {
 	}
 
 	w := csv.NewWriter(f)
-	w.Write([]string{"时间", "时间轴", "原文语言", "原文", "目标语言", "翻译"})
+	w.Write([]string{"时间", "时间轴", "原文语言", "原文", "目标语言", "翻译", "标记", "偏移毫秒"})
 	w.Flush()
 	if err := w.Error(); err != nil {
 		f.Close()
 		return nil, fmt.Errorf("write header: %w", err)
 	}
 
+	jf, err := os.Create(strings.TrimSuffix(path, ".csv") + ".jsonl")
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("create transcript file: %w", err)
+	}
+
 	return &Logger{
 		dir:       dir,
 		file:      f,
 		writer:    w,
+		jsonl:     jf,
 		roomID:    roomID,
 		name:      name,
 		session:   session,
@@ -124,11 +134,17 @@ func resumeRecent(dir, prefix string, roomID int64, name string) (*Logger, error
 	if err != nil {
 		return nil, err
 	}
+	jf, err := os.OpenFile(strings.TrimSuffix(bestPath, ".csv")+".jsonl", os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
 
 	return &Logger{
 		dir:       dir,
 		file:      f,
 		writer:    csv.NewWriter(f),
+		jsonl:     jf,
 		roomID:    roomID,
 		name:      name,
 		session:   bestSession,
@@ -136,22 +152,38 @@ func resumeRecent(dir, prefix string, roomID int64, name string) (*Logger, error
 	}, nil
 }
 
-// Write logs a multi-language translation entry.
-func (l *Logger) Write(sourceLang, source, targetLang, translated string) {
+// WriteRecord appends r to the JSONL file and its rows to the CSV.
+// r.Time defaults to now; r.Offset is computed from it.
+func (l *Logger) WriteRecord(r Record) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	if l.writer == nil {
 		return
 	}
-	now := time.Now()
-	ts := now.Format("15:04:05")
-	elapsed := now.Sub(l.startTime)
+	at := time.Now()
+	if r.Time > 0 {
+		at = time.UnixMilli(r.Time)
+	}
+	r.Time = at.UnixMilli()
+	elapsed := max(at.Sub(l.startTime), 0)
+	r.Offset = elapsed.Milliseconds()
+
+	if line, err := json.Marshal(r); err != nil {
+		slog.Error("transcript encode failed", "err", err)
+	} else if _, err := l.jsonl.Write(append(line, '\n')); err != nil {
+		slog.Error("transcript write failed", "err", err)
+	}
+
+	ts := at.Format("15:04:05")
 	minutes := int(elapsed.Minutes())
 	seconds := int(elapsed.Seconds()) % 60
 	timeline := fmt.Sprintf("%d:%02d", minutes, seconds)
-	if err := l.writer.Write([]string{ts, timeline, sourceLang, source, targetLang, translated}); err != nil {
-		slog.Error("transcript write failed", "err", err)
-		return
+	offset := strconv.FormatInt(r.Offset, 10)
+	for _, e := range r.entries() {
+		if err := l.writer.Write([]string{ts, timeline, e.SourceLang, e.Source, e.TargetLang, e.Text, e.Flag, offset}); err != nil {
+			slog.Error("transcript write failed", "err", err)
+			return
+		}
 	}
 	l.writer.Flush()
 	if err := l.writer.Error(); err != nil {
@@ -166,6 +198,9 @@ func (l *Logger) Close() error {
 	if l.writer != nil {
 		l.writer.Flush()
 	}
+	if l.jsonl != nil {
+		l.jsonl.Close()
+	}
 	if l.file != nil {
 		return l.file.Close()
 	}
@@ -193,7 +228,7 @@ func sanitize(s string) string {
 	return string(out)
 }
 
-// ListFiles returns all transcript CSV files, newest first.
+// ListFiles returns all transcript files (CSV and JSONL), newest first.
 func ListFiles(dir string) ([]FileInfo, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -0,0 +1,147 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Record types.
+const (
+	RecordUtterance = "utterance" // STT final routed to the outputs
+	RecordFiltered  = "filtered"  // STT final dropped by the noise filter
+	RecordManual    = "manual"    // operator-authored message
+	RecordDelivery  = "delivery"  // outcome reported after its record was written (resend, late approval)
+)
+
+// Delivery outcomes.
+const (
+	OutcomeSent    = "sent"
+	OutcomeFailed  = "failed"
+	OutcomeSkipped = "skipped" // skipped by an operator, or held and never approved
+	OutcomePaused  = "paused"  // output paused when the message arrived or was due
+	OutcomeDropped = "dropped" // moderation drop, or the output was removed
+	OutcomeNoText  = "no_text" // no translation for the output's language
+	OutcomePending = "pending" // still queued when the record was written
+)
+
+// Record is one line of the JSONL transcript: an STT result with every
+// translation and what each output did with it.
+type Record struct {
+	Type         string               `json:"type"`
+	Seq          int                  `json:"seq"`       // Translation.Seq, -1 for lines outside the pipeline
+	Time         int64                `json:"time"`      // unix ms of the STT final (manual: when queued)
+	Offset       int64                `json:"offset_ms"` // since session start, set by the Logger
+	SourceLang   string               `json:"source_lang,omitempty"`
+	SourceText   string               `json:"source_text,omitempty"`
+	Confidence   float32              `json:"confidence,omitempty"`
+	Filter       string               `json:"filter,omitempty"` // noise filter reason
+	Author       string               `json:"author,omitempty"` // manual: operator
+	Translations []Translation        `json:"translations,omitempty"`
+	Outputs      map[string]*Delivery `json:"outputs,omitempty"` // output name → outcome
+}
+
+// Translation is one target-language translation of a record.
+type Translation struct {
+	TargetLang string `json:"target_lang"`
+	Translator string `json:"translator"` // backend name
+	Model      string `json:"model,omitempty"`
+	Text       string `json:"text"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Delivery is what an output did with a record.
+type Delivery struct {
+	TargetLang string `json:"target_lang,omitempty"`
+	Outcome    string `json:"outcome"`
+	Text       string `json:"text,omitempty"`     // as queued, after masking and edits
+	Original   string `json:"original,omitempty"` // before the first operator edit
+	Edited     bool   `json:"edited,omitempty"`
+	EditedBy   string `json:"edited_by,omitempty"`
+	Held       bool   `json:"held,omitempty"`   // waited for approval
+	Reason     string `json:"reason,omitempty"` // moderation match, skip reason or error kind
+	Error      string `json:"error,omitempty"`
+	At         int64  `json:"at,omitempty"` // unix ms of the outcome
+}
+
+// entries derives the CSV rows of r: one per target language with text
+// (source only if none), the noise filter or operator flag, and one
+// "edited" row per distinct operator edit.
+func (r Record) entries() []Entry {
+	offset := time.Duration(r.Offset) * time.Millisecond
+	var out []Entry
+	switch r.Type {
+	case RecordUtterance:
+		seen := make(map[string]bool)
+		for _, t := range r.Translations {
+			if t.Text == "" || seen[t.TargetLang] {
+				continue
+			}
+			seen[t.TargetLang] = true
+			out = append(out, Entry{Offset: offset, SourceLang: r.SourceLang, Source: r.SourceText, TargetLang: t.TargetLang, Text: t.Text})
+		}
+		if len(out) == 0 {
+			out = append(out, Entry{Offset: offset, SourceLang: r.SourceLang, Source: r.SourceText})
+		}
+	case RecordFiltered:
+		out = append(out, Entry{Offset: offset, SourceLang: r.SourceLang, Source: r.SourceText, Flag: r.Filter})
+	case RecordManual:
+		for _, name := range r.outputNames() {
+			d := r.Outputs[name]
+			text := d.Text
+			if d.Edited {
+				text = d.Original
+			}
+			out = append(out, Entry{Offset: offset, TargetLang: d.TargetLang, Text: text, Flag: "manual:" + r.Author})
+		}
+	}
+
+	type edit struct{ lang, from, to string }
+	seen := make(map[edit]bool)
+	for _, name := range r.outputNames() {
+		d := r.Outputs[name]
+		e := edit{d.TargetLang, d.Original, d.Text}
+		if !d.Edited || seen[e] {
+			continue
+		}
+		seen[e] = true
+		out = append(out, Entry{Offset: offset, Source: d.Original, TargetLang: d.TargetLang, Text: d.Text, Flag: "edited"})
+	}
+	return out
+}
+
+func (r Record) outputNames() []string {
+	names := make([]string, 0, len(r.Outputs))
+	for name := range r.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadRecords parses a JSONL transcript.
+func ReadRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("read transcript line %d: %w", line, err)
+		}
+		records = append(records, r)
+	}
+	return records, sc.Err()
+}
@@ -0,0 +1,275 @@
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SourceLang selects the original STT text instead of a translation when
+// exporting subtitles.
+const SourceLang = "source"
+
+// Subtitle timing limits. A cue lasts as long as its text takes to read,
+// clamped to [minCueDuration, maxCueDuration], and ends before the next one.
+const (
+	minCueDuration = 1500 * time.Millisecond
+	maxCueDuration = 7 * time.Second
+	cueGap         = 50 * time.Millisecond
+)
+
+// Subtitle formats accepted by WriteSubtitles.
+var SubtitleFormats = []string{"srt", "vtt", "ass"}
+
+// Entry is one row of a transcript CSV.
+type Entry struct {
+	Offset     time.Duration // since session start
+	SourceLang string
+	Source     string
+	TargetLang string
+	Text       string
+	Flag       string
+}
+
+// Cue is one subtitle.
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// ReadFile parses a transcript CSV, or the CSV rows derived from a JSONL
+// transcript. CSV rows written before the millisecond offset column existed
+// fall back to the second-resolution timeline.
+func ReadFile(path string) ([]Entry, error) {
+	if strings.HasSuffix(path, ".jsonl") {
+		records, err := ReadRecords(path)
+		if err != nil {
+			return nil, err
+		}
+		var entries []Entry
+		for _, r := range records {
+			entries = append(entries, r.entries()...)
+		}
+		return entries, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		br.Discard(3)
+	}
+	r := csv.NewReader(br)
+	r.FieldsPerRecord = -1 // resumed files may mix old 7-column and new rows
+
+	var entries []Entry
+	header := true
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read transcript: %w", err)
+		}
+		if header {
+			header = false
+			continue
+		}
+		if len(rec) < 7 {
+			continue
+		}
+		e := Entry{SourceLang: rec[2], Source: rec[3], TargetLang: rec[4], Text: rec[5], Flag: rec[6]}
+		if len(rec) > 7 {
+			if ms, err := strconv.ParseInt(rec[7], 10, 64); err == nil {
+				e.Offset = time.Duration(ms) * time.Millisecond
+			}
+		} else {
+			e.Offset = parseTimeline(rec[1])
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// parseTimeline parses the "m:ss" timeline column.
+func parseTimeline(s string) time.Duration {
+	m, sec, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0
+	}
+	mi, _ := strconv.Atoi(m)
+	si, _ := strconv.Atoi(sec)
+	return time.Duration(mi)*time.Minute + time.Duration(si)*time.Second
+}
+
+// Languages returns the target languages with text in entries, sorted,
+// followed by SourceLang when there is source text.
+func Languages(entries []Entry) []string {
+	seen := make(map[string]bool)
+	hasSource := false
+	for _, e := range entries {
+		if e.Flag == "" && e.Source != "" {
+			hasSource = true
+		}
+		if e.TargetLang != "" && e.Text != "" && !isFiltered(e) {
+			seen[e.TargetLang] = true
+		}
+	}
+	langs := make([]string, 0, len(seen)+1)
+	for l := range seen {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	if hasSource {
+		langs = append(langs, SourceLang)
+	}
+	return langs
+}
+
+func isFiltered(e Entry) bool {
+	return e.Flag != "" && e.Flag != "edited" && !strings.HasPrefix(e.Flag, "manual:")
+}
+
+// Cues builds the subtitles of lang (a target language or SourceLang).
+// Operator edits replace the text of the cue they edited. shift moves every
+// cue, e.g. negative to compensate for STT and translation latency.
+func Cues(entries []Entry, lang string, shift time.Duration) []Cue {
+	var cues []Cue
+	for _, e := range entries {
+		if lang == SourceLang {
+			// One row is logged per target language; keep the first
+			if e.Flag != "" || e.Source == "" {
+				continue
+			}
+			if n := len(cues); n > 0 && cues[n-1].Text == e.Source && e.Offset-cues[n-1].Start < time.Second {
+				continue
+			}
+			cues = append(cues, Cue{Start: e.Offset, Text: e.Source})
+			continue
+		}
+		if e.TargetLang != lang || e.Text == "" || isFiltered(e) {
+			continue
+		}
+		if e.Flag == "edited" {
+			for i := len(cues) - 1; i >= 0; i-- {
+				if cues[i].Text == e.Source {
+					cues[i].Text = e.Text
+					break
+				}
+			}
+			continue
+		}
+		cues = append(cues, Cue{Start: e.Offset, Text: e.Text})
+	}
+
+	// Rows are written when every output is done with them, so a held
+	// message can come after later ones
+	sort.SliceStable(cues, func(i, j int) bool { return cues[i].Start < cues[j].Start })
+	for i := range cues {
+		cues[i].Start = max(cues[i].Start+shift, 0)
+	}
+	for i := range cues {
+		end := cues[i].Start + readDuration(cues[i].Text)
+		if i+1 < len(cues) {
+			if next := cues[i+1].Start - cueGap; next < end {
+				end = max(next, cues[i].Start+cueGap)
+			}
+		}
+		cues[i].End = end
+	}
+	return cues
+}
+
+// readDuration estimates how long text stays on screen: wide (CJK)
+// characters take longer to read than Latin ones.
+func readDuration(text string) time.Duration {
+	d := 500 * time.Millisecond
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			d += 200 * time.Millisecond
+		} else {
+			d += 60 * time.Millisecond
+		}
+	}
+	return min(max(d, minCueDuration), maxCueDuration)
+}
+
+// WriteSubtitles writes cues as an SRT, WebVTT or ASS file. title is used
+// by ASS only.
+func WriteSubtitles(w io.Writer, format string, cues []Cue, title string) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case "srt":
+		for i, c := range cues {
+			fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n\n", i+1, srtTime(c.Start, ','), srtTime(c.End, ','), cueText(c.Text))
+		}
+	case "vtt":
+		bw.WriteString("WEBVTT\n\n")
+		for i, c := range cues {
+			text := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(cueText(c.Text))
+			fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n\n", i+1, srtTime(c.Start, '.'), srtTime(c.End, '.'), text)
+		}
+	case "ass":
+		fmt.Fprintf(bw, assHeader, strings.ReplaceAll(title, "\n", " "))
+		for _, c := range cues {
+			text := strings.NewReplacer("\n", `\N`, "{", `\{`, "}", `\}`).Replace(cueText(c.Text))
+			fmt.Fprintf(bw, "Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n", assTime(c.Start), assTime(c.End), text)
+		}
+	default:
+		return fmt.Errorf("unknown subtitle format %q (srt, vtt, ass)", format)
+	}
+	return bw.Flush()
+}
+
+// cueText drops blank lines, which end a cue in SRT and WebVTT.
+func cueText(s string) string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// srtTime formats HH:MM:SS,mmm (SRT) or HH:MM:SS.mmm (WebVTT).
+func srtTime(d time.Duration, sep byte) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// assTime formats H:MM:SS.cc.
+func assTime(d time.Duration) string {
+	cs := d.Milliseconds() / 10
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
+
+const assHeader = `[Script Info]
+Title: %s
+ScriptType: v4.00+
+WrapStyle: 0
+ScaledBorderAndShadow: yes
+PlayResX: 1920
+PlayResY: 1080
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,60,&H00FFFFFF,&H000000FF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,3,1,2,40,40,50,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`
@@ -0,0 +1,244 @@
+package translate
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PromptVersion identifies the prompt wording. Bump it when instructions
+// change so cached translations from the old prompt are not reused.
+const PromptVersion = "v1"
+
+// CacheStore persists cached translations (implemented by auth.Store).
+type CacheStore interface {
+	GetCachedTranslation(text, sourceLang, targetLang, version string) (string, bool)
+	PutCachedTranslation(text, sourceLang, targetLang, version, translated string) error
+}
+
+// CacheStats are the cache counters since startup.
+type CacheStats struct {
+	Entries    int   `json:"entries"`     // in-memory entries
+	MemoryHits int64 `json:"memory_hits"` // served from the LRU
+	StoreHits  int64 `json:"store_hits"`  // served from SQLite
+	Misses     int64 `json:"misses"`      // sent to a backend
+}
+
+// Cache is an in-memory LRU in front of an optional persistent store.
+// Safe for concurrent use.
+type Cache struct {
+	store CacheStore // nil = memory only
+
+	mu    sync.Mutex
+	size  int
+	ll    *list.List               // front = most recently used
+	items map[string]*list.Element // key → element holding *cacheEntry
+
+	memHits   atomic.Int64
+	storeHits atomic.Int64
+	misses    atomic.Int64
+}
+
+type cacheEntry struct {
+	key        string
+	translated string
+}
+
+// NewCache creates a cache keeping up to size entries in memory.
+func NewCache(size int, store CacheStore) *Cache {
+	return &Cache{
+		store: store,
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get looks up a translation, first in memory, then in the store.
+func (c *Cache) Get(text, sourceLang, targetLang, version string) (string, bool) {
+	key := cacheKey(text, sourceLang, targetLang, version)
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		translated := el.Value.(*cacheEntry).translated
+		c.mu.Unlock()
+		c.memHits.Add(1)
+		return translated, true
+	}
+	c.mu.Unlock()
+
+	if c.store != nil {
+		if translated, ok := c.store.GetCachedTranslation(NormalizeText(text), sourceLang, targetLang, version); ok {
+			c.storeHits.Add(1)
+			c.add(key, translated)
+			return translated, true
+		}
+	}
+	c.misses.Add(1)
+	return "", false
+}
+
+// Put stores a translation in memory and in the store.
+func (c *Cache) Put(text, sourceLang, targetLang, version, translated string) {
+	if translated == "" {
+		return
+	}
+	c.add(cacheKey(text, sourceLang, targetLang, version), translated)
+	if c.store != nil {
+		if err := c.store.PutCachedTranslation(NormalizeText(text), sourceLang, targetLang, version, translated); err != nil {
+			slog.Warn("persist cached translation", "err", err)
+		}
+	}
+}
+
+func (c *Cache) add(key, translated string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*cacheEntry).translated = translated
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, translated: translated})
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Invalidate drops one entry from memory (after it was edited or deleted
+// in the store).
+func (c *Cache) Invalidate(text, sourceLang, targetLang, version string) {
+	key := cacheKey(text, sourceLang, targetLang, version)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+// Purge empties the in-memory cache.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+// Stats returns the current counters.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	n := c.ll.Len()
+	c.mu.Unlock()
+	return CacheStats{
+		Entries:    n,
+		MemoryHits: c.memHits.Load(),
+		StoreHits:  c.storeHits.Load(),
+		Misses:     c.misses.Load(),
+	}
+}
+
+// NormalizeText trims and collapses whitespace (including full-width
+// spaces) so trivially different STT results share a cache entry.
+func NormalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func cacheKey(text, sourceLang, targetLang, version string) string {
+	return NormalizeText(text) + "\x00" + sourceLang + "\x00" + targetLang + "\x00" + version
+}
+
+// cachedTranslator consults the cache before calling the wrapped backend.
+type cachedTranslator struct {
+	inner   Translator
+	cache   *Cache
+	backend string // registry name, part of the cache version
+}
+
+// cachedMultiTranslator is a cachedTranslator over a backend that batches.
+// Only it implements MultiTranslator, so callers don't batch requests the
+// backend would answer one by one (and count each miss twice).
+type cachedMultiTranslator struct {
+	*cachedTranslator
+	inner MultiTranslator
+}
+
+// Cached wraps t so results are served from and stored in c. The result
+// implements MultiTranslator if t does.
+func Cached(t Translator, c *Cache, backend string) Translator {
+	ct := &cachedTranslator{inner: t, cache: c, backend: backend}
+	if mt, ok := t.(MultiTranslator); ok {
+		return &cachedMultiTranslator{cachedTranslator: ct, inner: mt}
+	}
+	return ct
+}
+
+// version is the prompt/profile part of the cache key: prompt version,
+// backend, and the glossary terms that shaped the prompt.
+func (t *cachedTranslator) version(terms []Term) string {
+	v := PromptVersion + "/" + t.backend
+	if len(terms) > 0 {
+		h := sha256.New()
+		for _, term := range terms {
+			h.Write([]byte(term.Source + "\x00" + term.Target + "\x00"))
+		}
+		v += "/g" + hex.EncodeToString(h.Sum(nil))[:12]
+	}
+	return v
+}
+
+func (t *cachedTranslator) Translate(ctx context.Context, req Request) (string, error) {
+	version := t.version(req.Glossary)
+	if translated, ok := t.cache.Get(req.Text, req.SourceLang, req.TargetLang, version); ok {
+		slog.Debug("translation cache hit", "text", req.Text, "target", req.TargetLang)
+		reportModel(ctx, "", "cache")
+		return translated, nil
+	}
+	translated, err := t.inner.Translate(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	t.cache.Put(req.Text, req.SourceLang, req.TargetLang, version, translated)
+	return translated, nil
+}
+
+// TranslateMulti serves cached languages and asks the backend only for the
+// rest.
+func (t *cachedMultiTranslator) TranslateMulti(ctx context.Context, req MultiRequest) (map[string]string, error) {
+	out := make(map[string]string, len(req.TargetLangs))
+	var missing []string
+	for _, lang := range req.TargetLangs {
+		if translated, ok := t.cache.Get(req.Text, req.SourceLang, lang, t.version(req.Glossary[lang])); ok {
+			out[lang] = translated
+			reportModel(ctx, lang, "cache")
+		} else {
+			missing = append(missing, lang)
+		}
+	}
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	sub := req
+	sub.TargetLangs = missing
+	results, err := t.inner.TranslateMulti(ctx, sub)
+	if err != nil {
+		return out, err
+	}
+	for lang, translated := range results {
+		out[lang] = translated
+		t.cache.Put(req.Text, req.SourceLang, lang, t.version(req.Glossary[lang]), translated)
+	}
+	return out, nil
+}
+
+func (t *cachedTranslator) Close() {
+	t.inner.Close()
+}
@@ -0,0 +1,138 @@
+package translate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Turn is an earlier utterance included in the prompt as context.
+type Turn struct {
+	Source      string
+	Translation string // empty if the translation isn't available (yet)
+}
+
+// History is a rolling window of a streamer's recent utterances and their
+// translations, so the model can resolve pronouns, dropped subjects and
+// running jokes. Safe for concurrent use.
+type History struct {
+	mu     sync.Mutex
+	size   int // max turns kept
+	budget int // max estimated tokens handed to a prompt
+	turns  []historyTurn
+}
+
+type historyTurn struct {
+	seq          int
+	source       string
+	translations map[string]string // target lang → text
+}
+
+// NewHistory creates a history keeping the last size utterances and
+// returning at most tokenBudget (estimated) tokens of context.
+func NewHistory(size, tokenBudget int) *History {
+	return &History{size: size, budget: tokenBudget}
+}
+
+// Add records a new source utterance. Calls must be made in seq order.
+func (h *History) Add(seq int, source string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.turns = append(h.turns, historyTurn{seq: seq, source: source, translations: make(map[string]string)})
+	// Keep one extra turn: in-flight translations look back from seq-1
+	if len(h.turns) > h.size+1 {
+		h.turns = h.turns[len(h.turns)-h.size-1:]
+	}
+}
+
+// SetTranslation records the translation of utterance seq into targetLang.
+func (h *History) SetTranslation(seq int, targetLang, text string) {
+	if h == nil || text == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := len(h.turns) - 1; i >= 0; i-- {
+		if h.turns[i].seq == seq {
+			h.turns[i].translations[targetLang] = text
+			return
+		}
+	}
+}
+
+// Before returns up to size turns preceding seq, oldest first, with
+// translations into targetLang where known. Oldest turns are dropped
+// until the estimated token count fits the budget.
+func (h *History) Before(seq int, targetLang string) []Turn {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var out []Turn
+	tokens := 0
+	for i := len(h.turns) - 1; i >= 0 && len(out) < h.size; i-- {
+		ht := h.turns[i]
+		if ht.seq >= seq {
+			continue
+		}
+		t := Turn{Source: ht.source, Translation: ht.translations[targetLang]}
+		cost := estimateTokens(t.Source) + estimateTokens(t.Translation)
+		if h.budget > 0 && tokens+cost > h.budget {
+			break
+		}
+		tokens += cost
+		out = append(out, t)
+	}
+	// Reverse to oldest first
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// Reset clears the history (called when a live session ends).
+func (h *History) Reset() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.turns = nil
+}
+
+// estimateTokens roughly estimates LLM tokens: ~4 ASCII chars per token,
+// ~1 token per CJK/other character.
+func estimateTokens(s string) int {
+	ascii, other := 0, 0
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// contextBlock renders earlier turns for inclusion in a prompt.
+func contextBlock(turns []Turn) string {
+	if len(turns) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Earlier lines from the same stream, for context only (do NOT translate or repeat them):\n")
+	for _, t := range turns {
+		if t.Translation != "" {
+			fmt.Fprintf(&b, "- %s → %s\n", t.Source, t.Translation)
+		} else {
+			fmt.Fprintf(&b, "- %s\n", t.Source)
+		}
+	}
+	return b.String()
+}
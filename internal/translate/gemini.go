@@ -11,6 +11,12 @@ import (
 	"google.golang.org/genai"
 )
 
+func init() {
+	RegisterProvider("gemini", func(ctx context.Context, opts Options) (Translator, error) {
+		return NewGeminiTranslator(ctx, opts.APIKey, opts.Model)
+	})
+}
+
 // GeminiTranslator translates text using Gemini API.
 // Falls back to fallbackModel on 429/503, auto-recovers.
 type GeminiTranslator struct {
@@ -50,40 +56,18 @@ func WithFallbackModel(model string) TranslatorOption {
 	}
 }
 
-// Translate translates text from sourceLang to targetLang.
-func (t *GeminiTranslator) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+// Translate translates req.Text from req.SourceLang to req.TargetLang.
+func (t *GeminiTranslator) Translate(ctx context.Context, req Request) (string, error) {
+	text, sourceLang, targetLang := req.Text, req.SourceLang, req.TargetLang
 	if strings.TrimSpace(text) == "" {
 		return "", nil
 	}
 
-	prompt := fmt.Sprintf(
-		"Translate the following %s text to %s. "+
-			"Output ONLY the translation, nothing else. "+
-			"Keep it natural and concise (suitable for live stream subtitles). "+
-			"For proper nouns and person names, output their romaji/romanization instead of translating them.\n\n%s",
-		sourceLang, targetLang, text,
-	)
+	prompt := buildPrompt(req)
 
-	model := t.activeModel()
-	resp, err := t.client.Models.GenerateContent(ctx, model, genai.Text(prompt), nil)
+	resp, model, err := t.generate(ctx, prompt, nil)
 	if err != nil {
-		errStr := err.Error()
-		if strings.Contains(errStr, "429") || strings.Contains(errStr, "503") || strings.Contains(errStr, "RESOURCE_EXHAUSTED") || strings.Contains(errStr, "UNAVAILABLE") {
-			// Degrade to fallback for 30s
-			if !t.degraded.Load() {
-				slog.Warn("rate limited, falling back", "from", model, "to", t.fallbackModel, "duration", "30s")
-			}
-			t.degraded.Store(true)
-			t.recoverAt.Store(time.Now().Add(30 * time.Second).UnixMilli())
-
-			// Retry with fallback model
-			resp, err = t.client.Models.GenerateContent(ctx, t.fallbackModel, genai.Text(prompt), nil)
-			if err != nil {
-				return "", fmt.Errorf("gemini translate (fallback): %w", err)
-			}
-		} else {
-			return "", fmt.Errorf("gemini translate: %w", err)
-		}
+		return "", err
 	}
 
 	result := resp.Text()
@@ -97,6 +81,7 @@ func (t *GeminiTranslator) Translate(ctx context.Context, text, sourceLang, targ
 		if err2 == nil {
 			fallbackResult := strings.TrimSpace(resp2.Text())
 			if !looksLikeSource(fallbackResult, sourceLang, targetLang) {
+				reportModel(ctx, "", t.fallbackModel)
 				return fallbackResult, nil
 			}
 		}
@@ -105,17 +90,81 @@ func (t *GeminiTranslator) Translate(ctx context.Context, text, sourceLang, targ
 	}
 
 	slog.Debug("translated", "from", text, "to", result, "target", targetLang, "model", model)
+	reportModel(ctx, "", model)
 	return result, nil
 }
 
+// TranslateMulti translates req.Text into all req.TargetLangs with one
+// request, using a JSON response schema keyed by language code.
+func (t *GeminiTranslator) TranslateMulti(ctx context.Context, req MultiRequest) (map[string]string, error) {
+	if strings.TrimSpace(req.Text) == "" {
+		return map[string]string{}, nil
+	}
+
+	props := make(map[string]*genai.Schema, len(req.TargetLangs))
+	for _, lang := range req.TargetLangs {
+		props[lang] = &genai.Schema{Type: genai.TypeString}
+	}
+	cfg := &genai.GenerateContentConfig{
+		ResponseMIMEType: "application/json",
+		ResponseSchema: &genai.Schema{
+			Type:             genai.TypeObject,
+			Properties:       props,
+			Required:         req.TargetLangs,
+			PropertyOrdering: req.TargetLangs,
+		},
+	}
+
+	resp, model, err := t.generate(ctx, buildMultiPrompt(req), cfg)
+	if err != nil {
+		return nil, err
+	}
+	out, err := parseMulti(resp.Text(), req)
+	if err != nil {
+		return nil, err
+	}
+	slog.Debug("translated (batched)", "from", req.Text, "to", out, "model", model)
+	reportModel(ctx, "", model)
+	return out, nil
+}
+
+// generate runs prompt on the active model. On 429/503 it degrades to the
+// fallback model for 30s and retries there. Returns the model that answered.
+func (t *GeminiTranslator) generate(ctx context.Context, prompt string, cfg *genai.GenerateContentConfig) (*genai.GenerateContentResponse, string, error) {
+	model := t.activeModel()
+	resp, err := t.client.Models.GenerateContent(ctx, model, genai.Text(prompt), cfg)
+	if err == nil {
+		return resp, model, nil
+	}
+
+	errStr := err.Error()
+	if !strings.Contains(errStr, "429") && !strings.Contains(errStr, "503") && !strings.Contains(errStr, "RESOURCE_EXHAUSTED") && !strings.Contains(errStr, "UNAVAILABLE") {
+		return nil, model, fmt.Errorf("gemini translate: %w", err)
+	}
+
+	// Degrade to fallback for 30s
+	if !t.degraded.Load() {
+		slog.Warn("rate limited, falling back", "from", model, "to", t.fallbackModel, "duration", "30s")
+	}
+	t.degraded.Store(true)
+	t.recoverAt.Store(time.Now().Add(30 * time.Second).UnixMilli())
+
+	// Retry with fallback model
+	resp, err = t.client.Models.GenerateContent(ctx, t.fallbackModel, genai.Text(prompt), cfg)
+	if err != nil {
+		return nil, t.fallbackModel, fmt.Errorf("gemini translate (fallback): %w", err)
+	}
+	return resp, t.fallbackModel, nil
+}
+
 // looksLikeSource checks if the translation result is still in the source language.
 // Uses simple heuristic: for ja→zh, check if result contains mostly Japanese-specific chars.
 func looksLikeSource(text, sourceLang, targetLang string) bool {
 	if text == "" {
 		return false
 	}
-	srcShort := strings.SplitN(strings.ToLower(sourceLang), "-", 2)[0]
-	tgtShort := strings.SplitN(strings.ToLower(targetLang), "-", 2)[0]
+	srcShort := shortLang(sourceLang)
+	tgtShort := shortLang(targetLang)
 
 	if srcShort == tgtShort {
 		return false // same language, can't detect
@@ -0,0 +1,123 @@
+package translate
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Term is a glossary entry: a source term with a required translation.
+type Term struct {
+	Source     string
+	Target     string
+	TargetLang string   // "" = all target languages; "zh" matches any zh-*
+	Variants   []string // known wrong renderings, replaced with Target after translation
+}
+
+// Glossary is a streamer's terminology list (talent names, fan names,
+// segment names, forced romanizations).
+type Glossary []Term
+
+// Match returns the terms for targetLang whose source occurs in text.
+// Language-specific entries win over catch-all ones for the same source.
+func (g Glossary) Match(text, targetLang string) []Term {
+	if len(g) == 0 || text == "" {
+		return nil
+	}
+	lower := strings.ToLower(text)
+	picked := make(map[string]Term) // lowercase source → term
+	var order []string
+	for _, t := range g {
+		if t.Source == "" || !termLangMatch(t.TargetLang, targetLang) {
+			continue
+		}
+		key := strings.ToLower(t.Source)
+		if !strings.Contains(lower, key) {
+			continue
+		}
+		prev, seen := picked[key]
+		if !seen {
+			order = append(order, key)
+		}
+		if !seen || prev.TargetLang == "" {
+			picked[key] = t
+		}
+	}
+	out := make([]Term, 0, len(order))
+	for _, k := range order {
+		out = append(out, picked[k])
+	}
+	return out
+}
+
+// Enforce checks translated against the terms and, where the required
+// rendering is missing, replaces known wrong variants (or the untranslated
+// source term) with it. Returns the fixed text and the terms it forced.
+func Enforce(terms []Term, translated string) (string, []Term) {
+	var forced []Term
+	for _, t := range terms {
+		if t.Target == "" || containsFold(translated, t.Target) {
+			continue
+		}
+		fixed := translated
+		wrong := append([]string{t.Source}, t.Variants...)
+		for _, v := range wrong {
+			if v != "" && v != t.Target {
+				fixed = replaceFold(fixed, v, t.Target)
+			}
+		}
+		if fixed != translated {
+			translated = fixed
+			forced = append(forced, t)
+		} else {
+			slog.Debug("glossary term missing from translation", "source", t.Source, "target", t.Target, "text", translated)
+		}
+	}
+	return translated, forced
+}
+
+// glossaryBlock renders terms for inclusion in a prompt.
+func glossaryBlock(terms []Term) string {
+	if len(terms) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Glossary: always translate these terms exactly as given:\n")
+	for _, t := range terms {
+		fmt.Fprintf(&b, "- %s → %s\n", t.Source, t.Target)
+	}
+	return b.String()
+}
+
+// termLangMatch reports whether a term for termLang applies to targetLang.
+func termLangMatch(termLang, targetLang string) bool {
+	if termLang == "" || strings.EqualFold(termLang, targetLang) {
+		return true
+	}
+	// A bare language ("zh") covers all its regions
+	return !strings.Contains(termLang, "-") && strings.EqualFold(termLang, shortLang(targetLang))
+}
+
+func containsFold(s, sub string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(sub))
+}
+
+// replaceFold replaces all case-insensitive occurrences of old in s.
+func replaceFold(s, old, repl string) string {
+	lowerS, lowerOld := strings.ToLower(s), strings.ToLower(old)
+	if len(lowerS) != len(s) || len(lowerOld) != len(old) {
+		// Lowercasing changed byte lengths; fall back to exact matching
+		return strings.ReplaceAll(s, old, repl)
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lowerS, lowerOld)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		b.WriteString(repl)
+		s, lowerS = s[i+len(old):], lowerS[i+len(old):]
+	}
+}
@@ -0,0 +1,109 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterProvider("libretranslate", func(ctx context.Context, opts Options) (Translator, error) {
+		return NewLibreTranslator(opts.Endpoint, opts.APIKey)
+	})
+}
+
+// LibreTranslator translates text via a LibreTranslate server.
+type LibreTranslator struct {
+	endpoint string // base URL, e.g. http://localhost:5000
+	apiKey   string
+	client   *http.Client
+}
+
+// NewLibreTranslator creates a LibreTranslate client.
+func NewLibreTranslator(endpoint, apiKey string) (*LibreTranslator, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("libretranslate: endpoint not configured")
+	}
+	return &LibreTranslator{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// Translate translates req.Text from req.SourceLang to req.TargetLang.
+// LibreTranslate has no notion of context, so req.Context is ignored.
+func (t *LibreTranslator) Translate(ctx context.Context, req Request) (string, error) {
+	text, sourceLang, targetLang := req.Text, req.SourceLang, req.TargetLang
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+
+	source := libreLang(sourceLang)
+	if source == "" {
+		source = "auto"
+	}
+	body, err := json.Marshal(map[string]string{
+		"q":       text,
+		"source":  source,
+		"target":  libreLang(targetLang),
+		"format":  "text",
+		"api_key": t.apiKey,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", t.endpoint+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("libretranslate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read body: %w", err)
+	}
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+		Error          string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("libretranslate: status %d: parse json: %w", resp.StatusCode, err)
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("libretranslate: status %d: %s", resp.StatusCode, result.Error)
+	}
+
+	translated := strings.TrimSpace(result.TranslatedText)
+	slog.Debug("translated", "from", text, "to", translated, "target", targetLang, "model", "libretranslate")
+	return translated, nil
+}
+
+// libreLang maps a BCP-47 code to LibreTranslate's language codes.
+func libreLang(lang string) string {
+	switch strings.ToLower(lang) {
+	case "zh-tw", "zh-hk", "zh-hant":
+		return "zt"
+	}
+	if strings.HasPrefix(strings.ToLower(lang), "cmn") {
+		return "zh"
+	}
+	return shortLang(lang)
+}
+
+func (t *LibreTranslator) Close() {
+	t.client.CloseIdleConnections()
+}
@@ -0,0 +1,74 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MultiTranslator is implemented by backends that can translate one text
+// into several target languages with a single request.
+type MultiTranslator interface {
+	// TranslateMulti returns target lang → translation. Languages missing
+	// from the result (or with unusable output) should be retried one by one.
+	TranslateMulti(ctx context.Context, req MultiRequest) (map[string]string, error)
+}
+
+// MultiRequest is a translation of one text into several languages.
+type MultiRequest struct {
+	Text        string
+	SourceLang  string
+	TargetLangs []string
+	Context     []Turn            // earlier utterances, oldest first (optional)
+	Glossary    map[string][]Term // target lang → terms (optional)
+}
+
+// buildMultiPrompt returns the prompt for a batched request. The response
+// format itself is enforced by the backend (e.g. a JSON response schema).
+func buildMultiPrompt(req MultiRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		"Translate the following %s text into each of these languages: %s. "+
+			"Respond with a JSON object whose keys are exactly these language codes and whose values are the translations. "+
+			"Keep each translation natural and concise (suitable for live stream subtitles). "+
+			"For proper nouns and person names, output their romaji/romanization instead of translating them.\n\n",
+		req.SourceLang, strings.Join(req.TargetLangs, ", "),
+	)
+	extra := ""
+	for _, lang := range req.TargetLangs {
+		if block := glossaryBlock(req.Glossary[lang]); block != "" {
+			extra += "[" + lang + "] " + block
+		}
+	}
+	extra += contextBlock(req.Context)
+	if extra != "" {
+		b.WriteString(extra + "\nText to translate:\n")
+	}
+	b.WriteString(req.Text)
+	return b.String()
+}
+
+// parseMulti decodes a batched JSON response, keeping only requested
+// languages with non-empty, correctly translated values.
+func parseMulti(raw string, req MultiRequest) (map[string]string, error) {
+	raw = strings.TrimSpace(raw)
+	// Tolerate a markdown code fence around the JSON
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+
+	var m map[string]string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &m); err != nil {
+		return nil, fmt.Errorf("parse batched translation: %w", err)
+	}
+	out := make(map[string]string, len(req.TargetLangs))
+	for _, lang := range req.TargetLangs {
+		text := strings.TrimSpace(m[lang])
+		if text == "" || looksLikeSource(text, req.SourceLang, lang) {
+			continue
+		}
+		out[lang] = text
+	}
+	return out, nil
+}
@@ -0,0 +1,143 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterProvider("openai", func(ctx context.Context, opts Options) (Translator, error) {
+		return NewOpenAITranslator(opts.Endpoint, opts.APIKey, opts.Model)
+	})
+}
+
+// OpenAITranslator translates text via an OpenAI-compatible
+// chat-completions API (OpenAI, llama.cpp server, vLLM, ...).
+type OpenAITranslator struct {
+	endpoint string // base URL, e.g. http://localhost:8080/v1
+	apiKey   string
+	model    string
+	client   *http.Client
+}
+
+// NewOpenAITranslator creates a chat-completions translator.
+// An empty endpoint defaults to the OpenAI API.
+func NewOpenAITranslator(endpoint, apiKey, model string) (*OpenAITranslator, error) {
+	if endpoint == "" {
+		endpoint = "https://api.openai.com/v1"
+	}
+	if model == "" {
+		return nil, fmt.Errorf("openai translator: model not configured")
+	}
+	return &OpenAITranslator{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		apiKey:   apiKey,
+		model:    model,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Translate translates req.Text from req.SourceLang to req.TargetLang.
+func (t *OpenAITranslator) Translate(ctx context.Context, req Request) (string, error) {
+	text, sourceLang, targetLang := req.Text, req.SourceLang, req.TargetLang
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+
+	system := instructions(sourceLang, targetLang)
+	if block := glossaryBlock(req.Glossary); block != "" {
+		system += "\n\n" + block
+	}
+	if block := contextBlock(req.Context); block != "" {
+		system += "\n\n" + block
+	}
+	result, err := t.complete(ctx, []chatMessage{
+		{Role: "system", Content: system},
+		{Role: "user", Content: text},
+	})
+	if err != nil {
+		return "", err
+	}
+	if looksLikeSource(result, sourceLang, targetLang) {
+		slog.Warn("translation returned source language, skipping",
+			"model", t.model, "source", text, "result", result)
+		return "", nil
+	}
+
+	slog.Debug("translated", "from", text, "to", result, "target", targetLang, "model", t.model)
+	return result, nil
+}
+
+// complete sends a chat-completions request and returns the first choice.
+func (t *OpenAITranslator) complete(ctx context.Context, messages []chatMessage) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:       t.model,
+		Messages:    messages,
+		Temperature: 0.3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.endpoint+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai translate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read body: %w", err)
+	}
+	var result chatResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("openai translate: status %d: parse json: %w", resp.StatusCode, err)
+	}
+	if result.Error != nil {
+		return "", fmt.Errorf("openai translate: status %d: %s", resp.StatusCode, result.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK || len(result.Choices) == 0 {
+		return "", fmt.Errorf("openai translate: status %d, %d choices", resp.StatusCode, len(result.Choices))
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
+
+func (t *OpenAITranslator) Close() {
+	t.client.CloseIdleConnections()
+}
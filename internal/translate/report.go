@@ -0,0 +1,53 @@
+package translate
+
+import (
+	"context"
+	"sync"
+)
+
+// ModelReport records which model answered a translation request, for the
+// transcript. Backends that switch models (fallbacks, the cache) report
+// through the request ctx; others are described by Registry.Model.
+type ModelReport struct {
+	mu     sync.Mutex
+	model  string            // whole request
+	byLang map[string]string // batched requests answered per language
+}
+
+type modelReportKey struct{}
+
+// WithModelReport returns a ctx whose translations are reported to r.
+func WithModelReport(ctx context.Context) (context.Context, *ModelReport) {
+	r := &ModelReport{}
+	return context.WithValue(ctx, modelReportKey{}, r), r
+}
+
+// Model returns the model reported for targetLang, or for the whole
+// request. Empty if the backend reported nothing.
+func (r *ModelReport) Model(targetLang string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if m, ok := r.byLang[targetLang]; ok {
+		return m
+	}
+	return r.model
+}
+
+// reportModel records model in ctx's report. lang "" applies to every
+// language of the request.
+func reportModel(ctx context.Context, lang, model string) {
+	r, ok := ctx.Value(modelReportKey{}).(*ModelReport)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lang == "" {
+		r.model = model
+		return
+	}
+	if r.byLang == nil {
+		r.byLang = make(map[string]string)
+	}
+	r.byLang[lang] = model
+}
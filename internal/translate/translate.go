@@ -0,0 +1,202 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Translator translates text between languages.
+type Translator interface {
+	// Translate translates req.Text from req.SourceLang to req.TargetLang.
+	// An empty result with nil error means "nothing to send".
+	Translate(ctx context.Context, req Request) (string, error)
+	Close()
+}
+
+// Request is a single translation request.
+type Request struct {
+	Text       string
+	SourceLang string
+	TargetLang string
+	Context    []Turn // earlier utterances, oldest first (optional)
+	Glossary   []Term // terms occurring in Text that must be translated as given (optional)
+}
+
+// Options configures a translation backend.
+type Options struct {
+	Provider string // "gemini", "openai", "libretranslate"
+	APIKey   string
+	Model    string
+	Endpoint string // base URL for openai-compatible / libretranslate servers
+}
+
+// Factory creates a Translator from options.
+type Factory func(ctx context.Context, opts Options) (Translator, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]Factory)
+)
+
+// RegisterProvider makes a backend available under the given provider name.
+func RegisterProvider(provider string, f Factory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[provider] = f
+}
+
+// Providers returns the registered provider names, sorted.
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	out := make([]string, 0, len(providers))
+	for name := range providers {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ProviderName returns the provider used for provider ("" = gemini). It is
+// also the registry name of the default backend.
+func ProviderName(provider string) string {
+	if provider == "" {
+		return "gemini"
+	}
+	return provider
+}
+
+// New creates a Translator for opts.Provider ("" = gemini).
+func New(ctx context.Context, opts Options) (Translator, error) {
+	provider := ProviderName(opts.Provider)
+	providersMu.RLock()
+	f, ok := providers[provider]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown translation provider %q (available: %v)", provider, Providers())
+	}
+	return f(ctx, opts)
+}
+
+// Registry holds the configured translation backends by name.
+// The default backend is also reachable under its provider name.
+type Registry struct {
+	def     Translator
+	defName string
+	named   map[string]Translator
+	models  map[string]string // backend name → configured model (or provider)
+}
+
+// NewRegistry creates the default backend plus any named backends.
+func NewRegistry(ctx context.Context, def Options, backends map[string]Options) (*Registry, error) {
+	d, err := New(ctx, def)
+	if err != nil {
+		return nil, fmt.Errorf("default translator: %w", err)
+	}
+	defName := ProviderName(def.Provider)
+	r := &Registry{def: d, defName: defName, named: make(map[string]Translator), models: make(map[string]string)}
+	r.models[defName] = describeModel(def)
+	for name, opts := range backends {
+		t, err := New(ctx, opts)
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("translator %q: %w", name, err)
+		}
+		r.named[name] = t
+		r.models[name] = describeModel(opts)
+	}
+	return r, nil
+}
+
+// describeModel names the model of a backend, or its provider if the
+// model is left to the backend's default.
+func describeModel(opts Options) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	return ProviderName(opts.Provider)
+}
+
+// Name resolves a backend name like Get: empty or unknown names give the
+// default backend's name.
+func (r *Registry) Name(name string) string {
+	if _, ok := r.named[name]; ok && name != r.defName {
+		return name
+	}
+	return r.defName
+}
+
+// Model returns the configured model of the backend Get(name) returns.
+func (r *Registry) Model(name string) string {
+	return r.models[r.Name(name)]
+}
+
+// Get returns the backend registered under name.
+// Empty or unknown names resolve to the default backend.
+func (r *Registry) Get(name string) Translator {
+	if name == "" || name == r.defName {
+		return r.def
+	}
+	if t, ok := r.named[name]; ok {
+		return t
+	}
+	slog.Warn("unknown translator, using default", "name", name, "default", r.defName)
+	return r.def
+}
+
+// Names returns the default backend name followed by the named backends, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.named))
+	for name := range r.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{r.defName}, names...)
+}
+
+// UseCache routes all backends through c.
+func (r *Registry) UseCache(c *Cache) {
+	r.def = Cached(r.def, c, r.defName)
+	for name, t := range r.named {
+		r.named[name] = Cached(t, c, name)
+	}
+}
+
+// Close closes all backends.
+func (r *Registry) Close() {
+	r.def.Close()
+	for _, t := range r.named {
+		t.Close()
+	}
+}
+
+// instructions returns the system prompt shared by LLM backends.
+func instructions(sourceLang, targetLang string) string {
+	return fmt.Sprintf(
+		"Translate the following %s text to %s. "+
+			"Output ONLY the translation, nothing else. "+
+			"Keep it natural and concise (suitable for live stream subtitles). "+
+			"For proper nouns and person names, output their romaji/romanization instead of translating them.",
+		sourceLang, targetLang,
+	)
+}
+
+// buildPrompt returns the full single-turn prompt for req: instructions,
+// optional glossary and context, then the text to translate.
+func buildPrompt(req Request) string {
+	p := instructions(req.SourceLang, req.TargetLang) + "\n\n"
+	extra := glossaryBlock(req.Glossary) + contextBlock(req.Context)
+	if extra != "" {
+		p += extra + "\nText to translate:\n"
+	}
+	return p + req.Text
+}
+
+// shortLang returns the primary subtag of a language code ("ja-JP" → "ja").
+func shortLang(lang string) string {
+	return strings.SplitN(strings.ToLower(lang), "-", 2)[0]
+}
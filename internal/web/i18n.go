@@ -134,6 +134,102 @@ const I18N = {
     output_saved: '输出已保存',
     name_required: '名称必填',
     room_required: '房间号必填',
+    translator: '翻译引擎',
+    default_translator: '(默认引擎)',
+    glossary: '📖 术语表',
+    all_langs: '(全部语言)',
+    glossary_source: '原文术语',
+    glossary_target: '固定译法',
+    glossary_variants: '常见误译 (逗号分隔，翻译后强制替换)',
+    note: '备注',
+    no_glossary: '暂无术语',
+    glossary_required: '请选择主播并填写原文和译法',
+    saved: '已保存',
+    confirm_del_glossary: '确认删除该术语?',
+    translation_cache: '🗃️ 翻译缓存',
+    search: '搜索',
+    purge_cache: '清空缓存',
+    cache_disabled: '翻译缓存未启用',
+    cache_entries: '内存条目',
+    cache_memory_hits: '内存命中',
+    cache_store_hits: '数据库命中',
+    cache_misses: '未命中',
+    no_cache_entries: '暂无缓存',
+    translation: '译文',
+    version: '版本',
+    hits: '命中',
+    confirm_purge_cache: '确认清空全部翻译缓存?',
+    currently_saying: '正在说:',
+    sink_path: '文件路径 (file 平台)',
+    sink_max_len: '最大长度 (0=不限)',
+    stt_phrases: '识别提示词 (主播/粉丝/游戏名，逗号分隔)',
+    stt_boost: '提示权重',
+    stt_model_default: '默认模型',
+    stt_enhanced: '增强模型',
+    stt_profanity: '脏话过滤',
+    stt_word_offsets: '词级时间戳',
+    held: '待审核',
+    held_reason: '命中',
+    approve_btn: '放行',
+    mod_hold: '拦截待审',
+    mod_mask: '打码发送',
+    mod_drop: '直接丢弃',
+    mod_words: '屏蔽词 (逗号分隔)',
+    mod_lists: '词表文件 (逗号分隔，如B站敏感词)',
+    mod_patterns: '屏蔽正则 (每行一个)',
+    edited_by: '编辑者',
+    original: '原文本',
+    edit_pending: '修改待发送消息 (倒计时将重置)',
+    already_sent: '消息已发送或已跳过',
+    inject_btn: '📢 发送消息',
+    inject_prompt: '输入要通过此输出发送的消息',
+    inject_immediate: '立即发送 (跳过延迟)？取消则按正常延迟发送',
+    send_failed: '发送失败',
+    approval_required: '需人工放行',
+    send_delay: '发送延迟',
+    send_delay_prompt: '发送延迟秒数 (0=立即，留空=默认3秒)',
+    save_failed: '保存失败',
+    resend_btn: '重发',
+    dismiss_btn: '忽略',
+    send_health: '发送健康',
+    sends: '次发送',
+    failures: '次失败',
+    quarantined: '已隔离',
+    cooldown: '冷却中',
+    last_success: '最近成功',
+    unquarantine: '解除隔离',
+    err_not_logged_in: '账号未登录',
+    err_too_frequent: '发送过快',
+    err_sensitive: '敏感内容被拦截',
+    err_room_muted: '在房间被禁言',
+    err_msg_too_long: '弹幕过长',
+    expires_at: 'Cookie 过期时间',
+    accounts_invalid: '以下B站账号登录已失效，请重新扫码',
+    overlay_style: '字幕样式 (overlay)',
+    overlay_font: '字体',
+    overlay_size: '字号 (36)',
+    overlay_color: '颜色 (#fff)',
+    overlay_bottom: '底部',
+    overlay_top: '顶部',
+    overlay_lines: '行数 (2)',
+    overlay_fade: '淡出秒数 (0=不淡出)',
+    overlay_link: 'OBS 链接',
+    overlay_link_prompt: '在 OBS 中添加浏览器源，填入此链接：',
+    webhook_url: 'Webhook 地址',
+    webhook_secret: '签名密钥 (可选)',
+    webhook_timeout: '超时秒数 (5)',
+    webhook_retries: '重试次数 (2)',
+    twitch_accounts: '🟣 Twitch 聊天账号',
+    twitch_name: '名称 (默认 twitch:登录名)',
+    twitch_login: 'Twitch 登录名',
+    twitch_token: 'OAuth Token (oauth:...)',
+    twitch_channel: '频道 (twitch 平台)',
+    no_twitch_accounts: '暂无 Twitch 账号',
+    export_subtitles: '导出字幕',
+    no_subtitles: '没有可导出的字幕',
+    subtitle_source: '原文',
+    subtitle_shift: '偏移秒数 (负数提前)',
+    muted_in: '禁言房间',
   },
 
   en: {
@@ -260,6 +356,102 @@ const I18N = {
     output_saved: 'Output saved',
     name_required: 'Name required',
     room_required: 'Room ID required',
+    translator: 'Translator',
+    default_translator: '(default)',
+    glossary: '📖 Glossary',
+    all_langs: '(all languages)',
+    glossary_source: 'Source term',
+    glossary_target: 'Fixed translation',
+    glossary_variants: 'Known mistranslations (comma-separated, replaced after translation)',
+    note: 'Note',
+    no_glossary: 'No glossary entries',
+    glossary_required: 'Select a streamer and enter source and translation',
+    saved: 'Saved',
+    confirm_del_glossary: 'Delete this glossary entry?',
+    translation_cache: '🗃️ Translation Cache',
+    search: 'Search',
+    purge_cache: 'Purge cache',
+    cache_disabled: 'Translation cache is disabled',
+    cache_entries: 'In memory',
+    cache_memory_hits: 'Memory hits',
+    cache_store_hits: 'DB hits',
+    cache_misses: 'Misses',
+    no_cache_entries: 'No cached translations',
+    translation: 'Translation',
+    version: 'Version',
+    hits: 'Hits',
+    confirm_purge_cache: 'Purge the entire translation cache?',
+    currently_saying: 'Saying:',
+    sink_path: 'File path (file platform)',
+    sink_max_len: 'Max length (0 = none)',
+    stt_phrases: 'STT phrase hints (talent/fan/game names, comma separated)',
+    stt_boost: 'Hint boost',
+    stt_model_default: 'Default model',
+    stt_enhanced: 'Enhanced',
+    stt_profanity: 'Profanity filter',
+    stt_word_offsets: 'Word timestamps',
+    held: 'Held',
+    held_reason: 'Matched',
+    approve_btn: 'Approve',
+    mod_hold: 'Hold for approval',
+    mod_mask: 'Mask and send',
+    mod_drop: 'Drop',
+    mod_words: 'Blocked words (comma separated)',
+    mod_lists: 'Word list files (comma separated, e.g. Bilibili sensitive words)',
+    mod_patterns: 'Blocked regexes (one per line)',
+    edited_by: 'Edited by',
+    original: 'Original',
+    edit_pending: 'Edit pending message (countdown restarts)',
+    already_sent: 'Message already sent or skipped',
+    inject_btn: '📢 Send message',
+    inject_prompt: 'Message to send through this output',
+    inject_immediate: 'Send immediately (skip the delay)? Cancel to use the normal delay',
+    send_failed: 'Send failed',
+    approval_required: 'Approval required',
+    send_delay: 'Send delay',
+    send_delay_prompt: 'Send delay in seconds (0 = immediate, empty = default 3s)',
+    save_failed: 'Save failed',
+    resend_btn: 'Resend',
+    dismiss_btn: 'Dismiss',
+    send_health: 'Send health',
+    sends: 'sends',
+    failures: 'failures',
+    quarantined: 'Quarantined',
+    cooldown: 'Cooling down',
+    last_success: 'Last success',
+    unquarantine: 'Release',
+    err_not_logged_in: 'Not logged in',
+    err_too_frequent: 'Too frequent',
+    err_sensitive: 'Blocked as sensitive',
+    err_room_muted: 'Muted in room',
+    err_msg_too_long: 'Message too long',
+    expires_at: 'Cookie expires',
+    accounts_invalid: 'These Bilibili accounts are logged out, please scan the QR code again',
+    overlay_style: 'Subtitle style (overlay)',
+    overlay_font: 'Font',
+    overlay_size: 'Font size (36)',
+    overlay_color: 'Color (#fff)',
+    overlay_bottom: 'Bottom',
+    overlay_top: 'Top',
+    overlay_lines: 'Lines (2)',
+    overlay_fade: 'Fade after sec (0=never)',
+    overlay_link: 'OBS link',
+    overlay_link_prompt: 'Add a Browser Source in OBS with this URL:',
+    webhook_url: 'Webhook URL',
+    webhook_secret: 'Signing secret (optional)',
+    webhook_timeout: 'Timeout sec (5)',
+    webhook_retries: 'Retries (2)',
+    twitch_accounts: '🟣 Twitch Chat Accounts',
+    twitch_name: 'Name (default twitch:login)',
+    twitch_login: 'Twitch login',
+    twitch_token: 'OAuth token (oauth:...)',
+    twitch_channel: 'Channel (twitch)',
+    no_twitch_accounts: 'No Twitch accounts',
+    export_subtitles: 'Export subtitles',
+    no_subtitles: 'No subtitles to export',
+    subtitle_source: 'Original text',
+    subtitle_shift: 'Shift seconds (negative = earlier)',
+    muted_in: 'Muted in',
   },
 
   ja: {
@@ -386,6 +578,102 @@ const I18N = {
     output_saved: '出力を保存しました',
     name_required: '名前は必須です',
     room_required: 'ルームIDは必須です',
+    translator: '翻訳エンジン',
+    default_translator: '(デフォルト)',
+    glossary: '📖 用語集',
+    all_langs: '(全言語)',
+    glossary_source: '原文の用語',
+    glossary_target: '固定訳',
+    glossary_variants: 'よくある誤訳 (カンマ区切り、翻訳後に強制置換)',
+    note: 'メモ',
+    no_glossary: '用語がありません',
+    glossary_required: '配信者を選択し、原文と訳を入力してください',
+    saved: '保存しました',
+    confirm_del_glossary: 'この用語を削除しますか?',
+    translation_cache: '🗃️ 翻訳キャッシュ',
+    search: '検索',
+    purge_cache: 'キャッシュを消去',
+    cache_disabled: '翻訳キャッシュは無効です',
+    cache_entries: 'メモリ内',
+    cache_memory_hits: 'メモリヒット',
+    cache_store_hits: 'DBヒット',
+    cache_misses: 'ミス',
+    no_cache_entries: 'キャッシュがありません',
+    translation: '訳文',
+    version: 'バージョン',
+    hits: 'ヒット',
+    confirm_purge_cache: '翻訳キャッシュをすべて消去しますか?',
+    currently_saying: '発話中:',
+    sink_path: 'ファイルパス (file)',
+    sink_max_len: '最大長 (0=無制限)',
+    stt_phrases: '認識ヒント (配信者/ファン/ゲーム名、カンマ区切り)',
+    stt_boost: 'ヒント重み',
+    stt_model_default: '既定モデル',
+    stt_enhanced: '拡張モデル',
+    stt_profanity: '不適切語フィルタ',
+    stt_word_offsets: '単語タイムスタンプ',
+    held: '保留中',
+    held_reason: '該当',
+    approve_btn: '承認',
+    mod_hold: '保留して承認待ち',
+    mod_mask: '伏せ字で送信',
+    mod_drop: '破棄',
+    mod_words: 'ブロック語 (カンマ区切り)',
+    mod_lists: '単語リストファイル (カンマ区切り、例: bilibili NGワード)',
+    mod_patterns: 'ブロック正規表現 (1行に1つ)',
+    edited_by: '編集者',
+    original: '元のテキスト',
+    edit_pending: '送信待ちメッセージを編集 (カウントダウンはリセット)',
+    already_sent: 'メッセージは送信済みまたはスキップ済み',
+    inject_btn: '📢 メッセージ送信',
+    inject_prompt: 'この出力から送信するメッセージ',
+    inject_immediate: 'すぐに送信しますか (遅延なし)？キャンセルで通常の遅延',
+    send_failed: '送信に失敗しました',
+    approval_required: '承認必須',
+    send_delay: '送信遅延',
+    send_delay_prompt: '送信遅延の秒数 (0=即時、空欄=既定3秒)',
+    save_failed: '保存に失敗しました',
+    resend_btn: '再送信',
+    dismiss_btn: '無視',
+    send_health: '送信状態',
+    sends: '回送信',
+    failures: '回失敗',
+    quarantined: '隔離中',
+    cooldown: 'クールダウン中',
+    last_success: '最終成功',
+    unquarantine: '隔離解除',
+    err_not_logged_in: '未ログイン',
+    err_too_frequent: '送信頻度超過',
+    err_sensitive: 'センシティブ判定',
+    err_room_muted: 'ルームでミュート中',
+    err_msg_too_long: 'メッセージが長すぎます',
+    expires_at: 'Cookie有効期限',
+    accounts_invalid: '以下のBilibiliアカウントはログアウトしています。QRコードで再ログインしてください',
+    overlay_style: '字幕スタイル (overlay)',
+    overlay_font: 'フォント',
+    overlay_size: '文字サイズ (36)',
+    overlay_color: '色 (#fff)',
+    overlay_bottom: '下',
+    overlay_top: '上',
+    overlay_lines: '行数 (2)',
+    overlay_fade: 'フェード秒数 (0=なし)',
+    overlay_link: 'OBSリンク',
+    overlay_link_prompt: 'OBSでブラウザソースを追加し、このURLを入力してください：',
+    webhook_url: 'Webhook URL',
+    webhook_secret: '署名キー (任意)',
+    webhook_timeout: 'タイムアウト秒 (5)',
+    webhook_retries: 'リトライ回数 (2)',
+    twitch_accounts: '🟣 Twitch チャットアカウント',
+    twitch_name: '名前 (既定 twitch:ログイン名)',
+    twitch_login: 'Twitch ログイン名',
+    twitch_token: 'OAuth トークン (oauth:...)',
+    twitch_channel: 'チャンネル (twitch)',
+    no_twitch_accounts: 'Twitch アカウントがありません',
+    export_subtitles: '字幕を書き出す',
+    no_subtitles: '書き出せる字幕がありません',
+    subtitle_source: '原文',
+    subtitle_shift: 'ずらす秒数 (負で早める)',
+    muted_in: 'ミュート中',
   }
 };
 
@@ -395,6 +683,11 @@ function t(key) {
   return (I18N[currentLang] && I18N[currentLang][key]) || (I18N.zh[key]) || key;
 }
 
+// sendErrLabel names a send failure: the platform reason if known, else the error kind.
+function sendErrLabel(kind, reason) {
+  return reason ? t('err_' + reason) : kind;
+}
+
 function setLang(lang) {
   currentLang = lang;
   localStorage.setItem('livesub_lang', lang);
@@ -94,6 +94,8 @@ const indexHTML = `<!DOCTYPE html>
   .output-card { background: #0f3460; border-radius: 8px; padding: 15px; min-width: 250px; flex: 1; }
   .output-name { font-size: 15px; font-weight: bold; margin-bottom: 8px; }
   .output-info { font-size: 12px; color: #aaa; margin-bottom: 8px; }
+  .interim-line { font-size: 14px; color: #eee; min-height: 20px; margin-bottom: 14px; padding: 6px 10px; background: #0f3460; border-radius: 6px; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+  .interim-line.partial { color: #999; font-style: italic; }
   .output-text { font-size: 13px; color: #ccc; min-height: 30px; margin-bottom: 10px; word-break: break-all; }
   .badge-translating { background: #16213e; }
   .badge-paused { background: #e9a045; color: #000; }
@@ -114,6 +116,7 @@ const indexHTML = `<!DOCTYPE html>
     <a href="/api/logout" class="link-btn" data-i18n="logout">退出登录</a>
   </div>
 </div>
+<div id="alerts"></div>
 <div id="content"><div class="empty">加载中...</div></div>
 
 <div style="margin-top:30px;background:#16213e;border-radius:12px;padding:20px;">
@@ -130,6 +133,7 @@ document.getElementById('langSwitcherSlot').appendChild(
 );
 
 var currentUser = null;
+var interimState = {}; // streamer name → {text, final}
 
 async function init() {
   var res = await fetch('/api/me');
@@ -149,12 +153,53 @@ async function init() {
   };
   // Also poll as fallback (slower)
   setInterval(fetchStatus, 5000);
+  connectInterim();
+}
+
+// connectInterim subscribes to live STT hypotheses ("currently saying…").
+function connectInterim() {
+  var wsProto = location.protocol === 'https:' ? 'wss:' : 'ws:';
+  var ws = new WebSocket(wsProto + '//' + location.host + '/ws/interim');
+  ws.onmessage = function(e) {
+    var msg;
+    try { msg = JSON.parse(e.data); } catch(err) { return; }
+    interimState[msg.streamer] = {text: msg.text, final: msg.final};
+    document.querySelectorAll('.interim-line').forEach(function(el) {
+      if (el.getAttribute('data-streamer') === msg.streamer) renderInterim(el, interimState[msg.streamer]);
+    });
+  };
+  ws.onclose = function() { setTimeout(connectInterim, 3000); };
+}
+
+function renderInterim(el, st) {
+  if (!st || !st.text) {
+    el.className = 'interim-line partial';
+    el.textContent = '🗣️ …';
+    return;
+  }
+  el.className = 'interim-line' + (st.final ? '' : ' partial');
+  el.textContent = '🗣️ ' + (st.final ? '' : t('currently_saying') + ' ') + st.text + (st.final ? '' : '…');
+  el.title = st.text;
+}
+
+// renderAlerts warns about stored accounts that are no longer logged in.
+// Only /api/status carries them (admins), not the status WebSocket.
+function renderAlerts(data) {
+  var el = document.getElementById('alerts');
+  el.textContent = '';
+  var invalid = data.invalid_accounts || [];
+  if (invalid.length === 0) return;
+  var banner = document.createElement('div');
+  banner.style.cssText = 'margin-bottom:16px;padding:10px 14px;background:#3e1a1a;border:1px solid #e94560;border-radius:8px;color:#ff6b6b;font-size:14px';
+  banner.textContent = '⚠️ ' + t('accounts_invalid') + ': ' + invalid.join(', ');
+  el.appendChild(banner);
 }
 
 async function fetchStatus() {
   var res = await fetch('/api/status');
   if (res.status === 401) { window.location.href = '/login'; return; }
   var data = await res.json();
+  renderAlerts(data);
   renderStatus(data);
 }
 
@@ -196,6 +241,13 @@ function renderStatus(data) {
     statusDiv.appendChild(badge);
     card.appendChild(statusDiv);
 
+    // Live STT preview (updated by /ws/interim)
+    var interimEl = document.createElement('div');
+    interimEl.className = 'interim-line';
+    interimEl.setAttribute('data-streamer', s.name);
+    renderInterim(interimEl, interimState[s.name]);
+    card.appendChild(interimEl);
+
     var outputsDiv = document.createElement('div');
     outputsDiv.className = 'outputs';
 
@@ -240,9 +292,22 @@ function renderStatus(data) {
             var remaining = p.remain_sec || 0;
             var pText = document.createElement('span');
             pText.style.cssText = 'flex:1;color:#ccc;overflow:hidden;text-overflow:ellipsis;white-space:nowrap';
-            pText.textContent = remaining + 's | ' + p.text;
-            pText.title = p.text;
+            pText.textContent = (p.held ? '⛔ ' + t('held') : remaining + 's') + ' | ' + (p.author ? '👤' + p.author + ': ' : '') + (p.edited ? '✏️ ' : '') + p.text;
+            pText.title = p.held ? (p.reason === 'approval' ? t('approval_required') : t('held_reason') + ': ' + p.reason) + '\n' + p.text : p.text;
+            if (p.edited) pText.title += '\n' + t('edited_by') + ' ' + (p.edited_by || '') + ' | ' + t('original') + ': ' + p.original;
             pRow.appendChild(pText);
+            var editBtn = document.createElement('button');
+            editBtn.style.cssText = 'background:#0f3460;color:#fff;border:none;border-radius:4px;padding:2px 8px;cursor:pointer;font-size:12px;white-space:nowrap';
+            editBtn.textContent = t('edit');
+            editBtn.onclick = (function(sid, mid, txt) { return function(e) { e.stopPropagation(); editMsg(sid, mid, txt); }; })(s.name, p.id, p.text);
+            pRow.appendChild(editBtn);
+            if (p.held) {
+              var approveBtn = document.createElement('button');
+              approveBtn.style.cssText = 'background:#4ecca3;color:#fff;border:none;border-radius:4px;padding:2px 8px;cursor:pointer;font-size:12px;white-space:nowrap';
+              approveBtn.textContent = t('approve_btn');
+              approveBtn.onclick = (function(sid, mid) { return function(e) { e.stopPropagation(); approveMsg(sid, mid); }; })(s.name, p.id);
+              pRow.appendChild(approveBtn);
+            }
             var skipBtn = document.createElement('button');
             skipBtn.style.cssText = 'background:#e94560;color:#fff;border:none;border-radius:4px;padding:2px 8px;cursor:pointer;font-size:12px;white-space:nowrap';
             skipBtn.textContent = t('skip_btn');
@@ -252,6 +317,34 @@ function renderStatus(data) {
           });
         }
 
+        // Failed messages (after retries and account failover)
+        if (o.failed && o.failed.length > 0) {
+          var fLabel = document.createElement('div');
+          fLabel.style.cssText = 'font-size:12px;color:#ff6b6b;margin-top:8px;font-weight:bold';
+          fLabel.textContent = '❌ ' + t('send_failed') + ' (' + o.failed.length + ')';
+          oc.appendChild(fLabel);
+          o.failed.forEach(function(f) {
+            var fRow = document.createElement('div');
+            fRow.style.cssText = 'display:flex;align-items:center;gap:6px;margin:4px 0;padding:4px 8px;background:#3e1a1a;border-radius:4px;font-size:13px';
+            var fText = document.createElement('span');
+            fText.style.cssText = 'flex:1;color:#ccc;overflow:hidden;text-overflow:ellipsis;white-space:nowrap';
+            fText.textContent = sendErrLabel(f.kind, f.reason) + ' | ' + f.text;
+            fText.title = f.error + '\n' + f.text;
+            fRow.appendChild(fText);
+            var resendBtn = document.createElement('button');
+            resendBtn.style.cssText = 'background:#4ecca3;color:#fff;border:none;border-radius:4px;padding:2px 8px;cursor:pointer;font-size:12px;white-space:nowrap';
+            resendBtn.textContent = t('resend_btn');
+            resendBtn.onclick = (function(sid, mid) { return function(e) { e.stopPropagation(); failedAction(sid, mid, 'resend'); }; })(s.name, f.id);
+            fRow.appendChild(resendBtn);
+            var dismissBtn = document.createElement('button');
+            dismissBtn.style.cssText = 'background:#555;color:#fff;border:none;border-radius:4px;padding:2px 8px;cursor:pointer;font-size:12px;white-space:nowrap';
+            dismissBtn.textContent = t('dismiss_btn');
+            dismissBtn.onclick = (function(sid, mid) { return function(e) { e.stopPropagation(); failedAction(sid, mid, 'dismiss'); }; })(s.name, f.id);
+            fRow.appendChild(dismissBtn);
+            oc.appendChild(fRow);
+          });
+        }
+
         // Recent sent messages
         if (o.recent && o.recent.length > 0) {
           var rLabel = document.createElement('div');
@@ -313,10 +406,40 @@ function renderStatus(data) {
         asLabel.appendChild(asCb);
         asLabel.appendChild(document.createTextNode(t('auto_start_label') || '上播自动翻译'));
 
+        var apLabel = document.createElement('label');
+        apLabel.style.cssText = 'display:inline-flex;align-items:center;gap:4px;font-size:12px;color:#aaa;cursor:pointer;margin-left:12px;';
+        var apCb = document.createElement('input');
+        apCb.type = 'checkbox';
+        apCb.checked = o.require_approval || false;
+        apCb.onchange = (function(sn, out) { return function() { saveSendSettings(sn, out.name, out.send_delay, this.checked); }; })(s.name, o);
+        apLabel.appendChild(apCb);
+        apLabel.appendChild(document.createTextNode(t('approval_required')));
+
+        var delayBtn = document.createElement('button');
+        delayBtn.style.cssText = 'margin-left:12px;background:none;color:#aaa;border:1px solid #555;border-radius:4px;padding:1px 6px;cursor:pointer;font-size:12px;';
+        delayBtn.textContent = '⏱ ' + (o.send_delay || 0) + 's';
+        delayBtn.title = t('send_delay');
+        delayBtn.onclick = (function(sn, out) { return function() {
+          var v = prompt(t('send_delay_prompt'), String(out.send_delay || 0));
+          if (v === null) return;
+          var delay = v.trim() === '' ? null : parseInt(v);
+          if (delay !== null && (isNaN(delay) || delay < 0)) return;
+          saveSendSettings(sn, out.name, delay, out.require_approval || false);
+        }; })(s.name, o);
+
+        var injectBtn = document.createElement('button');
+        injectBtn.style.cssText = 'margin-left:auto;background:#0f3460;color:#fff;border:none;border-radius:4px;padding:2px 8px;cursor:pointer;font-size:12px;';
+        injectBtn.textContent = t('inject_btn');
+        injectBtn.disabled = !s.live;
+        injectBtn.onclick = (function(sn, on) { return function() { injectMsg(sn, on); }; })(s.name, o.name);
+
         var cbRow = document.createElement('div');
         cbRow.style.cssText = 'display:flex;align-items:center;margin-top:6px;';
         cbRow.appendChild(seqLabel);
         cbRow.appendChild(asLabel);
+        cbRow.appendChild(apLabel);
+        cbRow.appendChild(delayBtn);
+        cbRow.appendChild(injectBtn);
         oc.appendChild(cbRow);
 
         outputsDiv.appendChild(oc);
@@ -332,6 +455,54 @@ async function skipMsg(streamerName, msgId) {
   fetchStatus();
 }
 
+async function editMsg(streamerName, msgId, text) {
+  var edited = prompt(t('edit_pending'), text);
+  if (edited === null || !edited.trim() || edited === text) return;
+  var res = await fetch('/api/edit-pending?streamer=' + encodeURIComponent(streamerName) + '&id=' + msgId, {
+    method: 'POST', headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({text: edited})
+  });
+  if (!res.ok) alert(t('already_sent'));
+  fetchStatus();
+}
+
+async function saveSendSettings(streamerName, outputName, delay, approval) {
+  var res = await fetch('/api/send-settings?streamer=' + encodeURIComponent(streamerName) + '&output=' + encodeURIComponent(outputName), {
+    method: 'POST', headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({send_delay: delay, require_approval: approval})
+  });
+  if (!res.ok) {
+    var data = await res.json().catch(function() { return {}; });
+    alert(data.error || t('save_failed'));
+  }
+  fetchStatus();
+}
+
+async function injectMsg(streamerName, outputName) {
+  var text = prompt(t('inject_prompt') + ' (' + outputName + ')');
+  if (text === null || !text.trim()) return;
+  var immediate = confirm(t('inject_immediate'));
+  var res = await fetch('/api/inject?streamer=' + encodeURIComponent(streamerName) + '&output=' + encodeURIComponent(outputName), {
+    method: 'POST', headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({text: text, immediate: immediate})
+  });
+  if (!res.ok) {
+    var data = await res.json().catch(function() { return {}; });
+    alert(data.error || t('send_failed'));
+  }
+  fetchStatus();
+}
+
+async function failedAction(streamerName, msgId, action) {
+  await fetch('/api/failed?streamer=' + encodeURIComponent(streamerName) + '&id=' + msgId + '&action=' + action, {method: 'POST'});
+  fetchStatus();
+}
+
+async function approveMsg(streamerName, msgId) {
+  await fetch('/api/approve?streamer=' + encodeURIComponent(streamerName) + '&id=' + msgId, {method: 'POST'});
+  fetchStatus();
+}
+
 async function toggleSeq(streamerName, outputName) {
   await fetch('/api/toggle-seq?streamer=' + encodeURIComponent(streamerName) + '&output=' + encodeURIComponent(outputName));
 }
@@ -392,13 +563,77 @@ async function loadTranscripts() {
     dl.style.cssText = 'color:#4ecca3;text-decoration:none;font-size:13px;';
     dl.textContent = t('download');
     td4.appendChild(dl);
+    td4.appendChild(document.createTextNode(' '));
+    var ex = document.createElement('a');
+    ex.href = '#';
+    ex.style.cssText = 'color:#4ecca3;text-decoration:none;font-size:13px;';
+    ex.textContent = t('export_subtitles');
+    td4.appendChild(ex);
     tr.appendChild(td4);
 
     table.appendChild(tr);
+
+    var exRow = document.createElement('tr');
+    exRow.style.display = 'none';
+    var exTd = document.createElement('td');
+    exTd.colSpan = 4;
+    exTd.style.cssText = 'padding:6px;text-align:right;';
+    exRow.appendChild(exTd);
+    table.appendChild(exRow);
+    ex.onclick = function(e) {
+      e.preventDefault();
+      if (exRow.style.display === '') { exRow.style.display = 'none'; return; }
+      exRow.style.display = '';
+      showSubtitleExport(exTd, f.name);
+    };
   });
   el.appendChild(table);
 }
 
+// showSubtitleExport fills el with language/format pickers for a transcript.
+async function showSubtitleExport(el, file) {
+  el.textContent = '...';
+  var res = await fetch('/api/transcripts/export?file=' + encodeURIComponent(file));
+  if (!res.ok) { el.textContent = await res.text(); return; }
+  var info = await res.json();
+  el.textContent = '';
+  if (info.languages.length === 0) { el.textContent = t('no_subtitles'); return; }
+  var inputCss = 'padding:4px;border:1px solid #333;border-radius:4px;background:#0f3460;color:#eee;font-size:12px;margin-left:6px;';
+
+  var langSel = document.createElement('select');
+  langSel.style.cssText = inputCss;
+  info.languages.forEach(function(l) {
+    var o = document.createElement('option');
+    o.value = l;
+    o.textContent = l === 'source' ? t('subtitle_source') : l;
+    langSel.appendChild(o);
+  });
+  var fmtSel = document.createElement('select');
+  fmtSel.style.cssText = inputCss;
+  info.formats.forEach(function(f) {
+    var o = document.createElement('option');
+    o.value = f;
+    o.textContent = f.toUpperCase();
+    fmtSel.appendChild(o);
+  });
+  var shift = document.createElement('input');
+  shift.type = 'number';
+  shift.step = '0.1';
+  shift.placeholder = t('subtitle_shift');
+  shift.title = t('subtitle_shift');
+  shift.style.cssText = inputCss + 'width:110px;';
+  var go = document.createElement('a');
+  go.href = '#';
+  go.style.cssText = 'color:#4ecca3;text-decoration:none;font-size:13px;margin-left:8px;';
+  go.textContent = t('download');
+  go.onclick = function() {
+    go.href = '/api/transcripts/export?file=' + encodeURIComponent(file) +
+      '&lang=' + encodeURIComponent(langSel.value) + '&format=' + fmtSel.value +
+      '&shift=' + (parseFloat(shift.value) || 0);
+  };
+  [langSel, fmtSel, shift, go].forEach(function(n) { el.appendChild(n); });
+}
+
 init();
 </script>
 </body>
@@ -432,7 +667,7 @@ const adminHTML = `<!DOCTYPE html>
   .small-btn:hover { border-color: #e94560; color: #e94560; }
   .small-btn.danger:hover { border-color: #ff4444; color: #ff4444; }
   .form-row { display: flex; gap: 10px; margin-bottom: 10px; align-items: center; flex-wrap: wrap; }
-  .form-row input, .form-row select { padding: 8px 12px; border: 1px solid #333; border-radius: 6px; background: #0f3460; color: #eee; font-size: 14px; outline: none; }
+  .form-row input, .form-row select, .form-row textarea { padding: 8px 12px; border: 1px solid #333; border-radius: 6px; background: #0f3460; color: #eee; font-size: 14px; outline: none; }
   .form-row input:focus { border-color: #e94560; }
   .form-row input[type="text"], .form-row input[type="password"] { width: 160px; }
   .add-btn { padding: 8px 20px; border: none; border-radius: 6px; background: #4ecca3; color: #000; cursor: pointer; font-size: 14px; font-weight: bold; }
@@ -475,14 +710,74 @@ const adminHTML = `<!DOCTYPE html>
         <option value="es-ES">Español (es)</option>
         <option value="ru-RU">Русский (ru)</option>
       </select>
+      <select id="sTranslator" class="translatorSelect"></select>
       <button class="add-btn" onclick="saveStreamer()">保存</button>
     </div>
     <div class="form-row" style="margin-top:8px;">
       <input type="text" id="sCmdUIDs" placeholder="弹幕指令白名单 (UID逗号分隔)" style="flex:1;">
     </div>
+    <div class="form-row" style="margin-top:8px;">
+      <input type="text" id="sPhrases" data-i18n-placeholder="stt_phrases" placeholder="识别提示词 (主播/粉丝/游戏名，逗号分隔)" style="flex:1;">
+      <input type="number" id="sBoost" data-i18n-placeholder="stt_boost" placeholder="提示权重" step="0.5" min="0" max="20" style="width:100px;">
+      <select id="sModel">
+        <option value="" data-i18n="stt_model_default">默认模型</option>
+        <option value="latest_long">latest_long</option>
+        <option value="latest_short">latest_short</option>
+        <option value="video">video</option>
+        <option value="default">default</option>
+      </select>
+      <label style="font-size:13px;cursor:pointer;"><input type="checkbox" id="sEnhanced"> <span data-i18n="stt_enhanced">增强模型</span></label>
+      <label style="font-size:13px;cursor:pointer;"><input type="checkbox" id="sProfanity"> <span data-i18n="stt_profanity">脏话过滤</span></label>
+      <label style="font-size:13px;cursor:pointer;"><input type="checkbox" id="sWordOffsets"> <span data-i18n="stt_word_offsets">词级时间戳</span></label>
+    </div>
   </div>
 </div>
 
+<!-- Per-Streamer Glossary -->
+<div class="section admin-only">
+  <h2 data-i18n="glossary">📖 术语表</h2>
+  <div class="form-row" style="margin-bottom:15px;">
+    <span style="font-size:14px;color:#aaa;" data-i18n="select_streamer">选择主播</span>
+    <select id="glossaryStreamerSelect" onchange="loadGlossary()"></select>
+  </div>
+  <div id="glossaryTable"></div>
+  <div style="margin-top:15px;">
+    <div id="glossaryMsg" class="msg"></div>
+    <div class="form-row">
+      <input type="text" id="gSource" data-i18n-placeholder="glossary_source" placeholder="原文术语">
+      <input type="text" id="gTarget" data-i18n-placeholder="glossary_target" placeholder="固定译法">
+      <select id="gLang">
+        <option value="" data-i18n="all_langs">(全部语言)</option>
+        <option value="zh">中文 (zh)</option>
+        <option value="zh-CN">中文 (zh-CN)</option>
+        <option value="zh-TW">中文 (zh-TW)</option>
+        <option value="en">English (en)</option>
+        <option value="ja">日本語 (ja)</option>
+        <option value="ko">한국어 (ko)</option>
+      </select>
+      <button class="add-btn" onclick="saveGlossary()">保存</button>
+    </div>
+    <div class="form-row" style="margin-top:8px;">
+      <input type="text" id="gVariants" data-i18n-placeholder="glossary_variants" placeholder="常见误译 (逗号分隔，翻译后强制替换)" style="flex:1;">
+      <input type="text" id="gNote" data-i18n-placeholder="note" placeholder="备注">
+    </div>
+  </div>
+</div>
+
+<!-- Translation Cache -->
+<div class="section admin-only">
+  <h2 data-i18n="translation_cache">🗃️ 翻译缓存</h2>
+  <div class="form-row" style="margin-bottom:15px;">
+    <span id="cacheStats" style="font-size:13px;color:#aaa;"></span>
+  </div>
+  <div class="form-row" style="margin-bottom:15px;">
+    <input type="text" id="cacheQuery" data-i18n-placeholder="search" placeholder="搜索" style="flex:1;" onkeydown="if(event.key==='Enter')loadCache()">
+    <button class="small-btn" onclick="loadCache()" data-i18n="search">搜索</button>
+    <button class="small-btn danger" onclick="purgeCache()" data-i18n="purge_cache">清空缓存</button>
+  </div>
+  <div id="cacheTable"></div>
+</div>
+
 <!-- Per-Streamer Output Management -->
 <div class="section">
   <h2 data-i18n="output_mgmt">📤 输出管理</h2>
@@ -498,6 +793,12 @@ const adminHTML = `<!DOCTYPE html>
       <input type="text" id="outName" placeholder="名称">
       <select id="outPlatform">
         <option value="bilibili">bilibili</option>
+        <option value="console">console (dry-run)</option>
+        <option value="file">file (dry-run, JSONL)</option>
+        <option value="null">null (dry-run)</option>
+        <option value="overlay">overlay (OBS)</option>
+        <option value="webhook">webhook (JSON POST)</option>
+        <option value="twitch">twitch</option>
       </select>
       <select id="outLang">
         <option value="">(原文直传)</option>
@@ -510,14 +811,49 @@ const adminHTML = `<!DOCTYPE html>
         <option value="es-ES">Español (es-ES)</option>
         <option value="ru-RU">Русский (ru-RU)</option>
       </select>
+      <select id="outTranslator" class="translatorSelect"></select>
       <div id="outAccounts" style="display:inline-flex;gap:8px;flex-wrap:wrap;align-items:center;border:1px solid #555;border-radius:6px;padding:4px 8px;min-width:120px;"></div>
     </div>
     <div class="form-row">
       <input type="number" id="outRoom" placeholder="房间号 (0=默认)" style="width:120px;">
       <input type="text" id="outPrefix" placeholder="前缀" value="【" style="width:100px;">
       <input type="text" id="outSuffix" placeholder="后缀" value="】" style="width:100px;">
+      <input type="text" id="outPath" data-i18n-placeholder="sink_path" placeholder="文件路径 (file 平台)" style="width:180px;">
+      <input type="number" id="outMaxLen" data-i18n-placeholder="sink_max_len" placeholder="最大长度 (0=不限)" style="width:130px;">
+      <input type="text" id="outChannel" data-i18n-placeholder="twitch_channel" placeholder="频道 (twitch 平台)" style="width:150px;">
       <button class="add-btn" onclick="saveOutput()">保存</button>
     </div>
+    <div class="form-row">
+      <span style="font-size:13px;color:#aaa;" data-i18n="overlay_style">字幕样式 (overlay)</span>
+      <input type="text" id="ovFont" data-i18n-placeholder="overlay_font" placeholder="字体" style="width:140px;">
+      <input type="number" id="ovSize" data-i18n-placeholder="overlay_size" placeholder="字号 (36)" style="width:90px;">
+      <input type="text" id="ovColor" data-i18n-placeholder="overlay_color" placeholder="颜色 (#fff)" style="width:100px;">
+      <select id="ovPosition">
+        <option value="bottom" data-i18n="overlay_bottom">底部</option>
+        <option value="top" data-i18n="overlay_top">顶部</option>
+      </select>
+      <input type="number" id="ovLines" data-i18n-placeholder="overlay_lines" placeholder="行数 (2)" style="width:90px;">
+      <input type="number" id="ovFade" data-i18n-placeholder="overlay_fade" placeholder="淡出秒数 (0=不淡出)" style="width:150px;">
+    </div>
+    <div class="form-row">
+      <span style="font-size:13px;color:#aaa;">webhook</span>
+      <input type="text" id="whURL" data-i18n-placeholder="webhook_url" placeholder="Webhook URL" style="flex:1;">
+      <input type="text" id="whSecret" data-i18n-placeholder="webhook_secret" placeholder="签名密钥 (可选)" style="width:160px;">
+      <input type="number" id="whTimeout" data-i18n-placeholder="webhook_timeout" placeholder="超时秒数 (5)" style="width:120px;">
+      <input type="number" id="whRetries" data-i18n-placeholder="webhook_retries" placeholder="重试次数 (2)" style="width:120px;">
+    </div>
+    <div class="form-row">
+      <select id="modAction">
+        <option value="hold" data-i18n="mod_hold">拦截待审</option>
+        <option value="mask" data-i18n="mod_mask">打码发送</option>
+        <option value="drop" data-i18n="mod_drop">直接丢弃</option>
+      </select>
+      <input type="text" id="modWords" data-i18n-placeholder="mod_words" placeholder="屏蔽词 (逗号分隔)" style="flex:1;">
+      <input type="text" id="modLists" data-i18n-placeholder="mod_lists" placeholder="词表文件 (逗号分隔，如B站敏感词)" style="width:220px;">
+    </div>
+    <div class="form-row">
+      <textarea id="modPatterns" rows="2" data-i18n-placeholder="mod_patterns" placeholder="屏蔽正则 (每行一个)" style="flex:1;"></textarea>
+    </div>
   </div>
 </div>
 
@@ -560,6 +896,19 @@ const adminHTML = `<!DOCTYPE html>
   </div>
 </div>
 
+<!-- Twitch Accounts -->
+<div class="section admin-only">
+  <h2 data-i18n="twitch_accounts">🟣 Twitch 聊天账号</h2>
+  <div id="twitchTable"></div>
+  <div id="twitchMsg" class="msg"></div>
+  <div class="form-row" style="margin-top:15px;">
+    <input type="text" id="twName" data-i18n-placeholder="twitch_name" placeholder="名称 (默认 twitch:登录名)">
+    <input type="text" id="twLogin" data-i18n-placeholder="twitch_login" placeholder="Twitch 登录名">
+    <input type="password" id="twToken" data-i18n-placeholder="twitch_token" placeholder="OAuth Token (oauth:...)" style="width:220px;">
+    <button class="add-btn" onclick="addTwitchAccount()" data-i18n="add">添加</button>
+  </div>
+</div>
+
 <!-- Audit Log -->
 <div class="section admin-only">
   <h2 data-i18n="audit_log">📋 操作记录</h2>
@@ -583,6 +932,8 @@ document.getElementById('langSwitcherSlot').appendChild(
 var allAccounts = [];
 var allStreamers = [];
 var cachedOutputs = [];
+var allTranslators = [];
+var cachedGlossary = [];
 
 function escapeHTML(str) {
   if (!str) return '';
@@ -662,13 +1013,34 @@ async function init() {
     renderCheckboxes();
     loadUsers();
     loadBiliAccounts();
+    loadTwitchAccounts();
+    loadCache();
   } else {
     var acctsRes = await fetch('/api/my/accounts');
     allAccounts = await acctsRes.json() || [];
   }
+  var trRes = await fetch('/api/translators');
+  allTranslators = await trRes.json() || [];
+  renderTranslatorSelects();
   loadStreamers();
 }
 
+function renderTranslatorSelects() {
+  document.querySelectorAll('.translatorSelect').forEach(function(sel) {
+    sel.textContent = '';
+    var def = document.createElement('option');
+    def.value = '';
+    def.textContent = t('translator') + ': ' + t('default_translator');
+    sel.appendChild(def);
+    allTranslators.forEach(function(name) {
+      var opt = document.createElement('option');
+      opt.value = name;
+      opt.textContent = t('translator') + ': ' + name;
+      sel.appendChild(opt);
+    });
+  });
+}
+
 function renderCheckboxes() {
   var el = document.getElementById('accountCheckboxes');
   el.textContent = '';
@@ -705,6 +1077,8 @@ async function loadStreamers() {
     allStreamers = await res.json() || [];
     renderStreamersTable();
     renderRoomCheckboxes();
+    renderGlossarySelect();
+    loadGlossary();
   } else {
     // Non-admin: get streamers from status (filtered by permission)
     var res = await fetch('/api/status');
@@ -751,6 +1125,154 @@ function renderStreamersTable() {
   container.appendChild(buildTable([t('name'), t('room_id'), t('source_lang'), t('outputs'), t('cmd_whitelist'), t('actions')], rows));
 }
 
+// --- Glossary ---
+
+var editingGlossaryID = 0;
+
+function renderGlossarySelect() {
+  var sel = document.getElementById('glossaryStreamerSelect');
+  var prev = sel.value;
+  sel.textContent = '';
+  allStreamers.forEach(function(s) {
+    var opt = document.createElement('option');
+    opt.value = String(s.room_id);
+    opt.textContent = s.name + ' (#' + s.room_id + ')';
+    sel.appendChild(opt);
+  });
+  if (prev) sel.value = prev;
+}
+
+async function loadGlossary() {
+  var roomID = document.getElementById('glossaryStreamerSelect').value;
+  var container = document.getElementById('glossaryTable');
+  container.textContent = '';
+  if (!roomID) return;
+  var res = await fetch('/api/admin/glossary?room_id=' + roomID);
+  var entries = await res.json() || [];
+  cachedGlossary = entries;
+  if (entries.length === 0) {
+    var p = document.createElement('p');
+    p.style.cssText = 'text-align:center;color:#666;padding:15px;';
+    p.textContent = t('no_glossary');
+    container.appendChild(p);
+    return;
+  }
+  var rows = entries.map(function(e) {
+    var actions = document.createDocumentFragment();
+    actions.appendChild(makeBtn(t('edit'), 'small-btn', function() { editGlossary(e.id); }));
+    actions.appendChild(document.createTextNode(' '));
+    actions.appendChild(makeBtn(t('delete'), 'small-btn danger', function() { deleteGlossary(e.id); }));
+    return [e.source, e.target, e.target_lang || t('all_langs'), (e.variants || []).join(', '), e.note || '', actions];
+  });
+  container.appendChild(buildTable([t('glossary_source'), t('glossary_target'), t('target_lang'), t('glossary_variants'), t('note'), t('actions')], rows));
+}
+
+async function saveGlossary() {
+  var msgEl = document.getElementById('glossaryMsg');
+  var roomID = parseInt(document.getElementById('glossaryStreamerSelect').value) || 0;
+  var source = document.getElementById('gSource').value.trim();
+  var target = document.getElementById('gTarget').value.trim();
+  if (!roomID || !source || !target) { msgEl.className = 'msg err'; msgEl.textContent = t('glossary_required'); return; }
+  var variantsStr = document.getElementById('gVariants').value.trim();
+  var body = {
+    id: editingGlossaryID, room_id: roomID, source: source, target: target,
+    target_lang: document.getElementById('gLang').value,
+    variants: variantsStr ? variantsStr.split(/[,，]+/) : [],
+    note: document.getElementById('gNote').value.trim()
+  };
+  var res = await fetch('/api/admin/glossary', {
+    method: 'POST', headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify(body)
+  });
+  if (res.ok) {
+    msgEl.className = 'msg ok'; msgEl.textContent = t('saved') + ': ' + source + ' → ' + target;
+    clearGlossaryForm();
+    loadGlossary();
+  } else {
+    var data = await res.json();
+    msgEl.className = 'msg err'; msgEl.textContent = data.error || t('create_failed');
+  }
+}
+
+function editGlossary(id) {
+  var e = cachedGlossary.find(function(x) { return x.id === id; });
+  if (!e) return;
+  editingGlossaryID = e.id;
+  document.getElementById('gSource').value = e.source;
+  document.getElementById('gTarget').value = e.target;
+  document.getElementById('gLang').value = e.target_lang || '';
+  document.getElementById('gVariants').value = (e.variants || []).join(', ');
+  document.getElementById('gNote').value = e.note || '';
+  document.getElementById('gSource').scrollIntoView({behavior: 'smooth'});
+}
+
+async function deleteGlossary(id) {
+  if (!confirm(t('confirm_del_glossary'))) return;
+  await fetch('/api/admin/glossary?id=' + id, {method: 'DELETE'});
+  loadGlossary();
+}
+
+function clearGlossaryForm() {
+  editingGlossaryID = 0;
+  ['gSource', 'gTarget', 'gVariants', 'gNote'].forEach(function(id) { document.getElementById(id).value = ''; });
+  document.getElementById('gLang').selectedIndex = 0;
+}
+
+// --- Translation Cache ---
+
+async function loadCache() {
+  var q = document.getElementById('cacheQuery').value.trim();
+  var res = await fetch('/api/admin/translation-cache?q=' + encodeURIComponent(q));
+  var container = document.getElementById('cacheTable');
+  container.textContent = '';
+  if (!res.ok) {
+    document.getElementById('cacheStats').textContent = t('cache_disabled');
+    return;
+  }
+  var data = await res.json();
+  var st = data.stats || {};
+  document.getElementById('cacheStats').textContent =
+    t('cache_entries') + ': ' + st.entries + ' · ' + t('cache_memory_hits') + ': ' + st.memory_hits +
+    ' · ' + t('cache_store_hits') + ': ' + st.store_hits + ' · ' + t('cache_misses') + ': ' + st.misses;
+  var entries = data.entries || [];
+  if (entries.length === 0) {
+    var p = document.createElement('p');
+    p.style.cssText = 'text-align:center;color:#666;padding:15px;';
+    p.textContent = t('no_cache_entries');
+    container.appendChild(p);
+    return;
+  }
+  var rows = entries.map(function(e) {
+    var actions = document.createDocumentFragment();
+    actions.appendChild(makeBtn(t('edit'), 'small-btn', function() { editCacheEntry(e); }));
+    actions.appendChild(document.createTextNode(' '));
+    actions.appendChild(makeBtn(t('delete'), 'small-btn danger', function() { deleteCacheEntry(e.id); }));
+    return [e.text, e.source_lang + ' → ' + e.target_lang, e.translated, e.version, String(e.hits), actions];
+  });
+  container.appendChild(buildTable([t('source_text'), t('target_lang'), t('translation'), t('version'), t('hits'), t('actions')], rows));
+}
+
+async function editCacheEntry(e) {
+  var text = prompt(e.text, e.translated);
+  if (text === null || !text.trim()) return;
+  await fetch('/api/admin/translation-cache?id=' + e.id, {
+    method: 'PUT', headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({translated: text.trim()})
+  });
+  loadCache();
+}
+
+async function deleteCacheEntry(id) {
+  await fetch('/api/admin/translation-cache?id=' + id, {method: 'DELETE'});
+  loadCache();
+}
+
+async function purgeCache() {
+  if (!confirm(t('confirm_purge_cache'))) return;
+  await fetch('/api/admin/translation-cache?all=1', {method: 'DELETE'});
+  loadCache();
+}
+
 function renderStreamerSelect() {
   var sel = document.getElementById('outputStreamerSelect');
   var prev = sel.value; // remember current selection
@@ -773,17 +1295,31 @@ async function saveStreamer() {
   if (!roomID) { msgEl.className = 'msg err'; msgEl.textContent = t('room_required'); return; }
   var cmdUIDsStr = document.getElementById('sCmdUIDs').value.trim();
   var cmdUIDs = cmdUIDsStr ? cmdUIDsStr.split(/[,，\s]+/).map(Number).filter(function(n) { return n > 0; }) : [];
+  var phrasesStr = document.getElementById('sPhrases').value.trim();
   var existing = allStreamers.find(function(s) { return s.name === name; });
-  var outputs = existing ? existing.outputs : [];
+  // Start from the existing config so fields not on this form are kept
+  var body = Object.assign({outputs: []}, existing || {}, {
+    name: name, room_id: roomID, source_lang: lang, command_uids: cmdUIDs,
+    translator: document.getElementById('sTranslator').value,
+    stt: {
+      phrases: phrasesStr ? phrasesStr.split(/[,，]+/).map(function(p) { return p.trim(); }).filter(Boolean) : [],
+      boost: parseFloat(document.getElementById('sBoost').value) || 0,
+      model: document.getElementById('sModel').value,
+      enhanced: document.getElementById('sEnhanced').checked,
+      profanity_filter: document.getElementById('sProfanity').checked,
+      word_time_offsets: document.getElementById('sWordOffsets').checked
+    }
+  });
   var res = await fetch('/api/admin/streamers', {
     method: 'POST', headers: {'Content-Type': 'application/json'},
-    body: JSON.stringify({name: name, room_id: roomID, source_lang: lang, outputs: outputs, command_uids: cmdUIDs})
+    body: JSON.stringify(body)
   });
   if (res.ok) {
     msgEl.className = 'msg ok'; msgEl.textContent = t('streamer_saved') + ': ' + name;
     document.getElementById('sName').value = '';
     document.getElementById('sRoom').value = '';
     document.getElementById('sCmdUIDs').value = '';
+    setSTTForm({});
     loadStreamers();
   } else {
     var data = await res.json();
@@ -798,9 +1334,20 @@ function editStreamer(name) {
   document.getElementById('sRoom').value = s.room_id;
   document.getElementById('sLang').value = s.source_lang || 'ja-JP';
   document.getElementById('sCmdUIDs').value = (s.command_uids || []).join(', ');
+  document.getElementById('sTranslator').value = s.translator || '';
+  setSTTForm(s.stt || {});
   document.getElementById('sName').scrollIntoView({behavior: 'smooth'});
 }
 
+function setSTTForm(st) {
+  document.getElementById('sPhrases').value = (st.phrases || []).join(', ');
+  document.getElementById('sBoost').value = st.boost || '';
+  document.getElementById('sModel').value = st.model || '';
+  document.getElementById('sEnhanced').checked = !!st.enhanced;
+  document.getElementById('sProfanity').checked = !!st.profanity_filter;
+  document.getElementById('sWordOffsets').checked = !!st.word_time_offsets;
+}
+
 async function deleteStreamer(name) {
   if (!confirm(t('confirm_del_streamer') + ' ' + name + '?')) return;
   await fetch('/api/admin/streamers?name=' + encodeURIComponent(name), {method: 'DELETE'});
@@ -828,6 +1375,10 @@ async function loadStreamerOutputs() {
     actions.appendChild(makeBtn(t('edit'), 'small-btn', function() { editOutput(o.name); }));
     actions.appendChild(document.createTextNode(' '));
     actions.appendChild(makeBtn(t('delete'), 'small-btn danger', function() { deleteOutput(o.name); }));
+    if (o.platform === 'overlay' && o.overlay && o.overlay.token) {
+      actions.appendChild(document.createTextNode(' '));
+      actions.appendChild(makeBtn(t('overlay_link'), 'small-btn', function() { showOverlayLink(o.overlay.token); }));
+    }
     var acctDisplay = o.accounts && o.accounts.length > 0 ? o.accounts.join(', ') : (o.account || '');
     return [o.name, o.platform||'bilibili', o.target_lang||'(原文)', acctDisplay, String(o.room_id||0), o.prefix||'', o.suffix||'', actions];
   });
@@ -863,16 +1414,42 @@ async function saveOutput() {
   var msgEl = document.getElementById('outputMsg');
   if (!name) { msgEl.className = 'msg err'; msgEl.textContent = t('name_required'); return; }
   var selAccts = Array.from(document.querySelectorAll('.outAcctCb:checked')).map(function(c) { return c.value; });
-  var body = {
+  var existing = cachedOutputs.find(function(x) { return x.name === name; });
+  // Start from the existing config so fields not on this form are kept
+  var body = Object.assign({}, existing || {}, {
     name: name,
     platform: document.getElementById('outPlatform').value,
     target_lang: document.getElementById('outLang').value.trim(),
+    translator: document.getElementById('outTranslator').value,
     account: selAccts[0] || '',
     accounts: selAccts,
     room_id: parseInt(document.getElementById('outRoom').value) || 0,
     prefix: document.getElementById('outPrefix').value,
-    suffix: document.getElementById('outSuffix').value
-  };
+    suffix: document.getElementById('outSuffix').value,
+    path: document.getElementById('outPath').value.trim(),
+    max_len: parseInt(document.getElementById('outMaxLen').value) || 0,
+    channel: document.getElementById('outChannel').value.trim(),
+    overlay: Object.assign({}, (existing && existing.overlay) || {}, {
+      font: document.getElementById('ovFont').value.trim(),
+      font_size: parseInt(document.getElementById('ovSize').value) || 0,
+      color: document.getElementById('ovColor').value.trim(),
+      position: document.getElementById('ovPosition').value,
+      lines: parseInt(document.getElementById('ovLines').value) || 0,
+      fade: parseInt(document.getElementById('ovFade').value) || 0
+    }),
+    webhook: {
+      url: document.getElementById('whURL').value.trim(),
+      secret: document.getElementById('whSecret').value,
+      timeout: parseInt(document.getElementById('whTimeout').value) || 0,
+      retries: document.getElementById('whRetries').value === '' ? null : parseInt(document.getElementById('whRetries').value)
+    },
+    moderation: {
+      action: document.getElementById('modAction').value,
+      words: splitList(document.getElementById('modWords').value, /[,，]+/),
+      lists: splitList(document.getElementById('modLists').value, /[,，]+/),
+      patterns: splitList(document.getElementById('modPatterns').value, /\n+/)
+    }
+  });
   var res = await fetch((isAdmin ? '/api/admin/streamer-outputs' : '/api/my/streamer-outputs') + '?streamer=' + encodeURIComponent(streamerName), {
     method: 'POST', headers: {'Content-Type': 'application/json'},
     body: JSON.stringify(body)
@@ -893,12 +1470,33 @@ function editOutput(name) {
   document.getElementById('outName').value = o.name;
   document.getElementById('outPlatform').value = o.platform || 'bilibili';
   document.getElementById('outLang').value = o.target_lang || '';
+  document.getElementById('outTranslator').value = o.translator || '';
   // Check accounts in pool
   var pool = o.accounts && o.accounts.length > 0 ? o.accounts : (o.account ? [o.account] : []);
   document.querySelectorAll('.outAcctCb').forEach(function(cb) { cb.checked = pool.indexOf(cb.value) !== -1; });
   document.getElementById('outRoom').value = o.room_id || 0;
   document.getElementById('outPrefix').value = o.prefix || '';
   document.getElementById('outSuffix').value = o.suffix || '';
+  document.getElementById('outPath').value = o.path || '';
+  document.getElementById('outMaxLen').value = o.max_len || '';
+  document.getElementById('outChannel').value = o.channel || '';
+  var ov = o.overlay || {};
+  document.getElementById('ovFont').value = ov.font || '';
+  document.getElementById('ovSize').value = ov.font_size || '';
+  document.getElementById('ovColor').value = ov.color || '';
+  document.getElementById('ovPosition').value = ov.position || 'bottom';
+  document.getElementById('ovLines').value = ov.lines || '';
+  document.getElementById('ovFade').value = ov.fade || '';
+  var wh = o.webhook || {};
+  document.getElementById('whURL').value = wh.url || '';
+  document.getElementById('whSecret').value = wh.secret || '';
+  document.getElementById('whTimeout').value = wh.timeout || '';
+  document.getElementById('whRetries').value = wh.retries != null ? wh.retries : '';
+  var mod = o.moderation || {};
+  document.getElementById('modAction').value = mod.action || 'hold';
+  document.getElementById('modWords').value = (mod.words || []).join(', ');
+  document.getElementById('modLists').value = (mod.lists || []).join(', ');
+  document.getElementById('modPatterns').value = (mod.patterns || []).join('\n');
   document.getElementById('outName').scrollIntoView({behavior: 'smooth'});
 }
 
@@ -912,10 +1510,39 @@ async function deleteOutput(name) {
 function clearOutputForm() {
   document.getElementById('outName').value = '';
   document.getElementById('outLang').selectedIndex = 0;
+  document.getElementById('outTranslator').selectedIndex = 0;
   document.querySelectorAll('.outAcctCb').forEach(function(c) { c.checked = false; });
   document.getElementById('outRoom').value = '';
   document.getElementById('outPrefix').value = '【';
   document.getElementById('outSuffix').value = '】';
+  document.getElementById('outPlatform').selectedIndex = 0;
+  document.getElementById('outPath').value = '';
+  document.getElementById('outMaxLen').value = '';
+  document.getElementById('outChannel').value = '';
+  document.getElementById('ovFont').value = '';
+  document.getElementById('ovSize').value = '';
+  document.getElementById('ovColor').value = '';
+  document.getElementById('ovPosition').value = 'bottom';
+  document.getElementById('ovLines').value = '';
+  document.getElementById('ovFade').value = '';
+  document.getElementById('whURL').value = '';
+  document.getElementById('whSecret').value = '';
+  document.getElementById('whTimeout').value = '';
+  document.getElementById('whRetries').value = '';
+  document.getElementById('modAction').value = 'hold';
+  document.getElementById('modWords').value = '';
+  document.getElementById('modLists').value = '';
+  document.getElementById('modPatterns').value = '';
+}
+
+// showOverlayLink shows the OBS browser source URL of an overlay output.
+function showOverlayLink(token) {
+  prompt(t('overlay_link_prompt'), location.origin + '/overlay?token=' + encodeURIComponent(token));
+}
+
+// splitList splits a form value into trimmed non-empty items.
+function splitList(value, sep) {
+  return value.split(sep).map(function(v) { return v.trim(); }).filter(Boolean);
 }
 
 // --- User Management ---
@@ -1018,6 +1645,8 @@ async function deleteUser(id, name) {
 async function loadBiliAccounts() {
   var res = await fetch('/api/admin/bili-accounts');
   var accounts = await res.json() || [];
+  var health = {};
+  try { health = await (await fetch('/api/admin/bot-health')).json() || {}; } catch (e) {}
   var container = document.getElementById('biliTable');
   container.textContent = '';
 
@@ -1025,6 +1654,7 @@ async function loadBiliAccounts() {
     var statusEl = document.createElement('span');
     statusEl.style.color = a.valid ? '#4ecca3' : '#e94560';
     statusEl.textContent = a.valid ? t('valid') : t('invalid');
+    if (a.expires_at) statusEl.title = t('expires_at') + ': ' + new Date(a.expires_at).toLocaleString();
 
     var maxInput = document.createElement('input');
     maxInput.type = 'number';
@@ -1032,13 +1662,36 @@ async function loadBiliAccounts() {
     maxInput.style.cssText = 'width:60px;padding:4px;border:1px solid #333;border-radius:4px;background:#0f3460;color:#eee;font-size:13px;';
     maxInput.onchange = function() { updateBiliMax(a.id, this.value, this); };
 
-    var actions = makeBtn(t('delete'), 'small-btn danger', function() { deleteBiliAccount(a.id, a.name); });
+    var actions = document.createDocumentFragment();
+    var h = health[a.name];
+    if (h && (h.quarantined || mutedRooms(h).length > 0)) {
+      actions.appendChild(makeBtn(t('unquarantine'), 'small-btn', function() { unquarantineBot(a.name); }));
+      actions.appendChild(document.createTextNode(' '));
+    }
+    actions.appendChild(makeBtn(t('delete'), 'small-btn danger', function() { deleteBiliAccount(a.id, a.name); }));
+
+    var healthEl = document.createElement('span');
+    healthEl.style.cssText = 'font-size:12px;';
+    if (!h) {
+      healthEl.style.color = '#666';
+      healthEl.textContent = '-';
+    } else {
+      var parts = [h.sends + ' ' + t('sends') + ' / ' + h.failures + ' ' + t('failures')];
+      if (h.quarantined) parts.unshift('⛔ ' + t('quarantined'));
+      else if (h.cooldown_until > Date.now()) parts.unshift('⏳ ' + t('cooldown'));
+      if (mutedRooms(h).length > 0) parts.push('🔇 ' + t('muted_in') + ' #' + mutedRooms(h).join(', #'));
+      if (h.last_error_kind) parts.push(sendErrLabel(h.last_error_kind, h.last_reason) + (h.last_error_code ? ' (' + h.last_error_code + ')' : ''));
+      healthEl.style.color = h.quarantined ? '#e94560' : (h.consecutive_failures > 0 ? '#f0a500' : '#aaa');
+      healthEl.textContent = parts.join(' | ');
+      healthEl.title = (h.quarantine_reason ? h.quarantine_reason + '\n' : '') + (h.last_error || '') +
+        (h.last_success ? '\n' + t('last_success') + ': ' + new Date(h.last_success).toLocaleString() : '');
+    }
 
     var timeEl = document.createElement('span');
     timeEl.style.cssText = 'font-size:12px;color:#aaa;';
     timeEl.textContent = a.created_at || '';
 
-    return [a.name, String(a.uid || '-'), maxInput, timeEl, statusEl, actions];
+    return [a.name, String(a.uid || '-'), maxInput, timeEl, statusEl, healthEl, actions];
   });
   if (rows.length === 0) {
     var p = document.createElement('p');
@@ -1047,7 +1700,18 @@ async function loadBiliAccounts() {
     container.appendChild(p);
     return;
   }
-  container.appendChild(buildTable([t('name'), t('uid'), t('danmaku_max'), t('created_at'), t('status'), t('actions')], rows));
+  container.appendChild(buildTable([t('name'), t('uid'), t('danmaku_max'), t('created_at'), t('status'), t('send_health'), t('actions')], rows));
+}
+
+// mutedRooms lists the rooms a bot is currently skipped in after a mute.
+function mutedRooms(h) {
+  var now = Date.now();
+  return Object.keys(h.muted_rooms || {}).filter(function(room) { return h.muted_rooms[room] > now; });
+}
+
+async function unquarantineBot(name) {
+  await fetch('/api/admin/bot-health?action=unquarantine&name=' + encodeURIComponent(name), {method: 'POST'});
+  loadBiliAccounts();
 }
 
 async function updateBiliMax(id, val, inputEl) {
@@ -1068,6 +1732,84 @@ async function deleteBiliAccount(id, name) {
   loadBiliAccounts();
 }
 
+// --- Twitch Accounts ---
+
+async function loadTwitchAccounts() {
+  var res = await fetch('/api/admin/twitch-accounts');
+  var accounts = await res.json() || [];
+  var health = {};
+  try { health = await (await fetch('/api/admin/bot-health')).json() || {}; } catch (e) {}
+  var container = document.getElementById('twitchTable');
+  container.textContent = '';
+
+  var rows = accounts.map(function(a) {
+    var h = health[a.name];
+    var healthEl = document.createElement('span');
+    healthEl.style.cssText = 'font-size:12px;';
+    if (!h) {
+      healthEl.style.color = '#666';
+      healthEl.textContent = '-';
+    } else {
+      var parts = [h.sends + ' ' + t('sends') + ' / ' + h.failures + ' ' + t('failures')];
+      if (h.quarantined) parts.unshift('⛔ ' + t('quarantined'));
+      if (mutedRooms(h).length > 0) parts.push('🔇 ' + t('muted_in') + ' #' + mutedRooms(h).join(', #'));
+      if (h.last_error_kind) parts.push(sendErrLabel(h.last_error_kind, h.last_reason));
+      healthEl.style.color = h.quarantined ? '#e94560' : (h.consecutive_failures > 0 ? '#f0a500' : '#aaa');
+      healthEl.textContent = parts.join(' | ');
+      healthEl.title = h.last_error || '';
+    }
+
+    var actions = document.createDocumentFragment();
+    if (h && (h.quarantined || mutedRooms(h).length > 0)) {
+      actions.appendChild(makeBtn(t('unquarantine'), 'small-btn', function() { unquarantineBot(a.name).then(loadTwitchAccounts); }));
+      actions.appendChild(document.createTextNode(' '));
+    }
+    actions.appendChild(makeBtn(t('delete'), 'small-btn danger', function() { deleteTwitchAccount(a.id, a.name); }));
+
+    var timeEl = document.createElement('span');
+    timeEl.style.cssText = 'font-size:12px;color:#aaa;';
+    timeEl.textContent = a.created_at || '';
+
+    return [a.name, a.login, timeEl, healthEl, actions];
+  });
+  if (rows.length === 0) {
+    var p = document.createElement('p');
+    p.style.cssText = 'text-align:center;color:#666;padding:15px;';
+    p.textContent = t('no_twitch_accounts');
+    container.appendChild(p);
+    return;
+  }
+  container.appendChild(buildTable([t('name'), t('twitch_login'), t('created_at'), t('send_health'), t('actions')], rows));
+}
+
+async function addTwitchAccount() {
+  var msgEl = document.getElementById('twitchMsg');
+  var res = await fetch('/api/admin/twitch-accounts', {
+    method: 'POST', headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({
+      name: document.getElementById('twName').value.trim(),
+      login: document.getElementById('twLogin').value.trim(),
+      token: document.getElementById('twToken').value.trim()
+    })
+  });
+  if (res.ok) {
+    msgEl.className = 'msg ok'; msgEl.textContent = t('saved');
+    document.getElementById('twName').value = '';
+    document.getElementById('twLogin').value = '';
+    document.getElementById('twToken').value = '';
+    loadTwitchAccounts();
+  } else {
+    var data = await res.json();
+    msgEl.className = 'msg err'; msgEl.textContent = data.error || t('create_failed');
+  }
+}
+
+async function deleteTwitchAccount(id, name) {
+  if (!confirm(t('confirm_del_account') + ' ' + name + '?')) return;
+  await fetch('/api/admin/twitch-accounts?id=' + id, {method: 'DELETE'});
+  loadTwitchAccounts();
+}
+
 var qrPollTimer = null;
 
 async function startQRLogin() {
@@ -1126,3 +1868,73 @@ init();
 </body>
 </html>`
 
+
+// overlayHTML is the subtitle page for "overlay" outputs, meant as an OBS
+// browser source. Style comes from the output config over the WebSocket.
+const overlayHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>LiveSub Overlay</title>
+<style>
+  html, body { margin: 0; padding: 0; background: transparent; overflow: hidden; width: 100%; height: 100%; }
+  #subs { position: fixed; left: 0; right: 0; bottom: 40px; display: flex; flex-direction: column; align-items: center; gap: 8px; padding: 0 40px; }
+  #subs.top { top: 40px; bottom: auto; }
+  .line { color: #fff; font-family: sans-serif; font-size: 36px; font-weight: bold; text-align: center; line-height: 1.3;
+          text-shadow: -2px -2px 0 #000, 2px -2px 0 #000, -2px 2px 0 #000, 2px 2px 0 #000, 0 0 8px rgba(0,0,0,0.8);
+          transition: opacity 0.6s; }
+  .line.fading { opacity: 0; }
+</style>
+</head>
+<body>
+<div id="subs"></div>
+<script>
+var token = new URLSearchParams(location.search).get('token') || '';
+var cfg = {};
+var subs = document.getElementById('subs');
+
+function applyConfig(c) {
+  cfg = c || {};
+  subs.className = cfg.position === 'top' ? 'top' : '';
+  subs.textContent = '';
+}
+
+function addLine(text, time) {
+  var fade = (cfg.fade || 0) * 1000;
+  var age = Date.now() - (time || Date.now());
+  if (fade > 0 && age >= fade) return;
+
+  var el = document.createElement('div');
+  el.className = 'line';
+  el.textContent = text;
+  if (cfg.font) el.style.fontFamily = cfg.font;
+  if (cfg.font_size) el.style.fontSize = cfg.font_size + 'px';
+  if (cfg.color) el.style.color = cfg.color;
+  subs.appendChild(el);
+
+  var max = cfg.lines || 2;
+  while (subs.children.length > max) subs.removeChild(subs.firstChild);
+
+  if (fade > 0) {
+    setTimeout(function() {
+      el.classList.add('fading');
+      setTimeout(function() { if (el.parentNode) el.parentNode.removeChild(el); }, 600);
+    }, fade - age);
+  }
+}
+
+function connect() {
+  var proto = location.protocol === 'https:' ? 'wss:' : 'ws:';
+  var ws = new WebSocket(proto + '//' + location.host + '/ws/overlay?token=' + encodeURIComponent(token));
+  ws.onmessage = function(e) {
+    var msg;
+    try { msg = JSON.parse(e.data); } catch (err) { return; }
+    if (msg.type === 'config') applyConfig(msg.config);
+    else if (msg.type === 'line') addLine(msg.text, msg.time);
+  };
+  ws.onclose = function() { setTimeout(connect, 3000); };
+}
+connect();
+</script>
+</body>
+</html>`
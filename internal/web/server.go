@@ -1,7 +1,9 @@
 package web
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +24,7 @@ import (
 	"github.com/christian-lee/livesub/internal/config"
 	"github.com/christian-lee/livesub/internal/controller"
 	"github.com/christian-lee/livesub/internal/transcript"
+	"github.com/christian-lee/livesub/internal/translate"
 )
 
 // StreamerState tracks per-streamer state for the web UI.
@@ -31,10 +35,14 @@ type StreamerState struct {
 	Outputs  []controller.OutputState `json:"outputs"`
 }
 
-// StatusResponse is the /api/status response.
+// StatusResponse is the /api/status response. /ws/status pushes it without
+// Bots and InvalidAccounts, which need a login.
 type StatusResponse struct {
-	Streamers []StreamerState `json:"streamers"`
-	BotNames  []string        `json:"bot_names"`
+	Streamers []StreamerState       `json:"streamers"`
+	BotNames  []string              `json:"bot_names"`
+	Bots      map[string]bot.Health `json:"bots,omitempty"` // send health per bot; non-admins get the bots of their outputs
+
+	InvalidAccounts []string `json:"invalid_accounts,omitempty"` // admins only: stored Bilibili accounts that are logged out
 }
 
 // session stores user info
@@ -61,14 +69,31 @@ type Server struct {
 	onAccountChange  func()
 	onStreamerChange func()
 	transcriptDir   string
+	cache           *translate.Cache // nil when caching is disabled
 
-	mu        sync.RWMutex
-	streamers map[string]*streamerRuntime // streamer name → runtime state
+	mu              sync.RWMutex
+	streamers       map[string]*streamerRuntime // streamer name → runtime state
+	invalidAccounts []string                    // from the bili_accounts valid flag
 
 	// WebSocket clients for live status push
 	wsMu      sync.Mutex
 	wsConns   map[*websocket.Conn]bool
 	wsBroadch chan struct{} // coalesce rapid broadcasts
+
+	// WebSocket clients for live interim STT preview
+	interimMu    sync.Mutex
+	interimConns map[*websocket.Conn]map[int64]bool // conn → allowed rooms (nil = all)
+	interimCh    chan InterimMessage
+}
+
+// InterimMessage is pushed on /ws/interim: what the streamer is currently
+// saying. A final message replaces the preceding interim hypotheses.
+type InterimMessage struct {
+	Streamer string `json:"streamer"`
+	RoomID   int64  `json:"room_id"`
+	Text     string `json:"text"`
+	Lang     string `json:"lang,omitempty"`
+	Final    bool   `json:"final"`
 }
 
 func NewServer(pool *bot.Pool, port int, store *auth.Store, transcriptDir string, cfg *config.Config, cfgPath string) *Server {
@@ -82,6 +107,8 @@ func NewServer(pool *bot.Pool, port int, store *auth.Store, transcriptDir string
 		streamers:     make(map[string]*streamerRuntime),
 		wsConns:       make(map[*websocket.Conn]bool),
 		wsBroadch:     make(chan struct{}, 1),
+		interimConns:  make(map[*websocket.Conn]map[int64]bool),
+		interimCh:     make(chan InterimMessage, 64),
 	}
 	// Load persisted sessions
 	s.store.CleanExpiredSessions()
@@ -146,6 +173,11 @@ func (s *Server) SetController(streamerName string, ctrl *controller.Controller)
 	}
 }
 
+// SetTranslationCache sets the translation cache managed from the admin panel.
+func (s *Server) SetTranslationCache(c *translate.Cache) {
+	s.cache = c
+}
+
 // SetLive updates live status for a streamer.
 // When going live, auto_start outputs are unpaused for the new session.
 func (s *Server) SetLive(streamerName string, live bool) {
@@ -182,27 +214,39 @@ func (s *Server) getOrCreateRuntime(name string) *streamerRuntime {
 }
 
 func (s *Server) Start() {
+	s.refreshInvalidAccounts()
 	go s.runWSBroadcast()
+	go s.runInterimBroadcast()
 	mux := http.NewServeMux()
 
 	// Public
 	mux.HandleFunc("/login", s.handleLoginPage)
 	mux.HandleFunc("/api/login", s.handleLogin)
 	mux.HandleFunc("/api/logout", s.handleLogout)
+	mux.HandleFunc("/overlay", s.handleOverlayPage) // token-authenticated, for OBS
+	mux.HandleFunc("/ws/overlay", s.handleOverlayWS)
 
 	// Authenticated
 	mux.HandleFunc("/", s.requireAuth(s.handleIndex))
 	mux.HandleFunc("/api/status", s.requireAuth(s.handleStatus))
 	mux.HandleFunc("/ws/status", s.handleWS)
+	mux.HandleFunc("/ws/interim", s.requireAuth(s.handleInterimWS))
 	mux.HandleFunc("/api/toggle", s.requireAuth(s.handleToggle))
 	mux.HandleFunc("/api/toggle-seq", s.requireAuth(s.handleToggleSeq))
 	mux.HandleFunc("/api/toggle-autostart", s.requireAuth(s.handleToggleAutoStart))
 	mux.HandleFunc("/api/skip", s.requireAuth(s.handleSkip))
+	mux.HandleFunc("/api/approve", s.requireAuth(s.handleApprove))
+	mux.HandleFunc("/api/edit-pending", s.requireAuth(s.handleEditPending))
+	mux.HandleFunc("/api/inject", s.requireAuth(s.handleInject))
+	mux.HandleFunc("/api/send-settings", s.requireAuth(s.handleSendSettings))
+	mux.HandleFunc("/api/failed", s.requireAuth(s.handleFailed))
 	mux.HandleFunc("/api/me", s.requireAuth(s.handleMe))
 	mux.HandleFunc("/api/transcripts", s.requireAuth(s.handleTranscripts))
 	mux.HandleFunc("/api/transcripts/download", s.requireAuth(s.handleTranscriptDownload))
+	mux.HandleFunc("/api/transcripts/export", s.requireAuth(s.handleTranscriptExport))
 	mux.HandleFunc("/api/my/streamer-outputs", s.requireAuth(s.handleMyStreamerOutputs))
 	mux.HandleFunc("/api/my/accounts", s.requireAuth(s.handleMyAccounts))
+	mux.HandleFunc("/api/translators", s.requireAuth(s.handleTranslators))
 	// /settings removed — merged into /admin
 
 	// Admin only
@@ -212,11 +256,15 @@ func (s *Server) Start() {
 	mux.HandleFunc("/api/admin/all-accounts", s.requireAdmin(s.handleAdminAllAccounts))
 	mux.HandleFunc("/api/admin/audit", s.requireAdmin(s.handleAdminAudit))
 	mux.HandleFunc("/api/admin/bili-accounts", s.requireAdmin(s.handleBiliAccounts))
+	mux.HandleFunc("/api/admin/bot-health", s.requireAdmin(s.handleBotHealth))
 	mux.HandleFunc("/api/admin/bili-account", s.requireAdmin(s.handleBiliAccount))
 	mux.HandleFunc("/api/admin/bili-qr/generate", s.requireAdmin(s.handleBiliQRGenerate))
 	mux.HandleFunc("/api/admin/bili-qr/poll", s.requireAdmin(s.handleBiliQRPoll))
+	mux.HandleFunc("/api/admin/twitch-accounts", s.requireAdmin(s.handleTwitchAccounts))
 	mux.HandleFunc("/api/admin/streamers", s.requireAdmin(s.handleAdminStreamers))
 	mux.HandleFunc("/api/admin/streamer-outputs", s.requireAdmin(s.handleAdminStreamerOutputs))
+	mux.HandleFunc("/api/admin/glossary", s.requireAdmin(s.handleAdminGlossary))
+	mux.HandleFunc("/api/admin/translation-cache", s.requireAdmin(s.handleAdminTranslationCache))
 
 	addr := fmt.Sprintf(":%d", s.port)
 	slog.Info("web control panel started", "addr", addr)
@@ -423,6 +471,8 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 					Paused:     paused,
 					ShowSeq:    o.ShowSeq,
 					AutoStart:  o.AutoStart,
+					SendDelay:  int(controller.SendDelay(o) / time.Second),
+					Approval:   o.RequireApproval,
 				}
 			}
 		}
@@ -437,6 +487,23 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	resp := StatusResponse{
 		Streamers: streamers,
 		BotNames:  s.pool.Names(),
+		Bots:      s.pool.Health(),
+	}
+	if u.IsAdmin {
+		resp.InvalidAccounts = s.invalidAccounts
+	} else {
+		// Only the health of the bots sending for the outputs shown
+		mine := make(map[string]bot.Health)
+		for _, st := range streamers {
+			for _, o := range st.Outputs {
+				for _, name := range o.BotNames {
+					if h, ok := resp.Bots[name]; ok {
+						mine[name] = h
+					}
+				}
+			}
+		}
+		resp.Bots = mine
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -506,6 +573,63 @@ func (s *Server) handleToggleSeq(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, `{"error":"not found"}`, 404)
 }
 
+// handleSendSettings updates an output's send delay and approval mode live.
+// Body: {"send_delay": 3, "require_approval": false}; send_delay null = default.
+func (s *Server) handleSendSettings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != "POST" {
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+		return
+	}
+	streamerName := r.URL.Query().Get("streamer")
+	outputName := r.URL.Query().Get("output")
+	var req struct {
+		SendDelay       *int  `json:"send_delay"`
+		RequireApproval *bool `json:"require_approval"` // nil = unchanged
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, 400)
+		return
+	}
+	if req.SendDelay != nil && (*req.SendDelay < 0 || *req.SendDelay > 600) {
+		http.Error(w, `{"error":"send_delay must be 0-600 seconds"}`, 400)
+		return
+	}
+
+	s.mu.Lock()
+	for i := range s.cfg.Streamers {
+		if s.cfg.Streamers[i].Name != streamerName {
+			continue
+		}
+		for j := range s.cfg.Streamers[i].Outputs {
+			o := &s.cfg.Streamers[i].Outputs[j]
+			if o.Name != outputName {
+				continue
+			}
+			o.SendDelay = req.SendDelay
+			if req.RequireApproval != nil {
+				o.RequireApproval = *req.RequireApproval
+			}
+			updated := *o
+			rt := s.streamers[streamerName]
+			s.mu.Unlock()
+			if rt != nil && rt.ctrl != nil {
+				rt.ctrl.UpdateOutput(updated)
+			}
+			config.Save(s.cfgPath, s.cfg)
+			delay := "default"
+			if req.SendDelay != nil {
+				delay = fmt.Sprintf("%ds", *req.SendDelay)
+			}
+			s.audit(r, "send_settings", fmt.Sprintf("%s / %s delay=%s approval=%v", streamerName, outputName, delay, updated.RequireApproval))
+			json.NewEncoder(w).Encode(map[string]any{"ok": true})
+			return
+		}
+	}
+	s.mu.Unlock()
+	http.Error(w, `{"error":"not found"}`, 404)
+}
+
 func (s *Server) handleToggleAutoStart(w http.ResponseWriter, r *http.Request) {
 	streamerName := r.URL.Query().Get("streamer")
 	outputName := r.URL.Query().Get("output")
@@ -615,6 +739,7 @@ func (s *Server) doBroadcast() {
 				state.Outputs[i] = controller.OutputState{
 					Name: o.Name, Platform: o.Platform, TargetLang: o.TargetLang,
 					BotName: o.Account, BotNames: o.AccountPool(), Paused: paused, ShowSeq: o.ShowSeq,
+					SendDelay: int(controller.SendDelay(o) / time.Second), Approval: o.RequireApproval,
 				}
 			}
 		}
@@ -633,6 +758,177 @@ func (s *Server) doBroadcast() {
 	}
 }
 
+// handleInterimWS streams interim STT hypotheses for the rooms the user may see.
+func (s *Server) handleInterimWS(w http.ResponseWriter, r *http.Request) {
+	u := s.getUser(r)
+	if u == nil {
+		http.Error(w, `{"error":"unauthorized"}`, 401)
+		return
+	}
+	var rooms map[int64]bool
+	if !u.IsAdmin {
+		if ids, _ := s.store.GetUserRooms(u.ID); len(ids) > 0 {
+			rooms = make(map[int64]bool)
+			for _, rid := range ids {
+				rooms[rid] = true
+			}
+		}
+	}
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Warn("ws upgrade failed", "err", err)
+		return
+	}
+	s.interimMu.Lock()
+	s.interimConns[conn] = rooms
+	s.interimMu.Unlock()
+
+	defer func() {
+		s.interimMu.Lock()
+		delete(s.interimConns, conn)
+		s.interimMu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+}
+
+// overlayConfig returns the overlay config of the output using token.
+func (s *Server) overlayConfig(token string) (config.OverlayConfig, bool) {
+	if token == "" {
+		return config.OverlayConfig{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sc := range s.cfg.Streamers {
+		for _, o := range sc.Outputs {
+			if o.Platform == "overlay" && subtle.ConstantTimeCompare([]byte(o.Overlay.Token), []byte(token)) == 1 {
+				return o.Overlay, true
+			}
+		}
+	}
+	return config.OverlayConfig{}, false
+}
+
+// handleOverlayPage serves the subtitle overlay for an OBS browser source.
+func (s *Server) handleOverlayPage(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.overlayConfig(r.URL.Query().Get("token")); !ok {
+		http.Error(w, "invalid token", 403)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, overlayHTML)
+}
+
+// overlayMessage is pushed on /ws/overlay: the page style on connect, then
+// subtitle lines.
+type overlayMessage struct {
+	Type   string                `json:"type"` // "config" or "line"
+	Config *config.OverlayConfig `json:"config,omitempty"`
+	Text   string                `json:"text,omitempty"`
+	Time   int64                 `json:"time,omitempty"` // unix ms
+}
+
+// handleOverlayWS streams an overlay output's lines to its page.
+func (s *Server) handleOverlayWS(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	oc, ok := s.overlayConfig(token)
+	if !ok {
+		http.Error(w, "invalid token", 403)
+		return
+	}
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Warn("ws upgrade failed", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	recent, lines, cancel := bot.SubscribeOverlay(token)
+	defer cancel()
+
+	oc.Token = ""
+	if err := conn.WriteJSON(overlayMessage{Type: "config", Config: &oc}); err != nil {
+		return
+	}
+	for _, l := range recent {
+		if err := conn.WriteJSON(overlayMessage{Type: "line", Text: l.Text, Time: l.Time}); err != nil {
+			return
+		}
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-closed:
+			return
+		case l := <-lines:
+			conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			if err := conn.WriteJSON(overlayMessage{Type: "line", Text: l.Text, Time: l.Time}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// interimFinalWait bounds how long BroadcastInterim waits to queue a final
+// when clients can't keep up.
+const interimFinalWait = 2 * time.Second
+
+// BroadcastInterim queues an STT hypothesis for /ws/interim clients.
+// Hypotheses are dropped if clients can't keep up; finals, which replace
+// the line on the panels, wait up to interimFinalWait.
+func (s *Server) BroadcastInterim(streamer string, roomID int64, text, lang string, final bool) {
+	msg := InterimMessage{Streamer: streamer, RoomID: roomID, Text: text, Lang: lang, Final: final}
+	select {
+	case s.interimCh <- msg:
+		return
+	default:
+	}
+	if !final {
+		return
+	}
+	timer := time.NewTimer(interimFinalWait)
+	defer timer.Stop()
+	select {
+	case s.interimCh <- msg:
+	case <-timer.C:
+		slog.Warn("interim final dropped", "streamer", streamer)
+	}
+}
+
+// runInterimBroadcast is the single goroutine that writes to interim WS connections.
+func (s *Server) runInterimBroadcast() {
+	for msg := range s.interimCh {
+		data, _ := json.Marshal(msg)
+		s.interimMu.Lock()
+		for c, rooms := range s.interimConns {
+			if rooms != nil && !rooms[msg.RoomID] {
+				continue
+			}
+			c.SetWriteDeadline(time.Now().Add(time.Second))
+			if err := c.WriteMessage(websocket.TextMessage, data); err != nil {
+				delete(s.interimConns, c)
+				c.Close()
+			}
+		}
+		s.interimMu.Unlock()
+	}
+}
+
 func (s *Server) handleSkip(w http.ResponseWriter, r *http.Request) {
 	streamerName := r.URL.Query().Get("streamer")
 	msgIDStr := r.URL.Query().Get("id")
@@ -654,6 +950,191 @@ func (s *Server) handleSkip(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]any{"ok": true, "skipped": msgID})
 }
 
+// handleApprove releases a message held by moderation.
+func (s *Server) handleApprove(w http.ResponseWriter, r *http.Request) {
+	streamerName := r.URL.Query().Get("streamer")
+	msgID, _ := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+
+	s.mu.RLock()
+	rt, ok := s.streamers[streamerName]
+	var ctrl *controller.Controller
+	if ok {
+		ctrl = rt.ctrl
+	}
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if ctrl == nil || !ctrl.ApprovePending(msgID) {
+		http.Error(w, `{"error":"held message not found"}`, 404)
+		return
+	}
+	s.audit(r, "approve_message", fmt.Sprintf("%s id=%d", streamerName, msgID))
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "approved": msgID})
+}
+
+// handleEditPending replaces the text of a pending message.
+// Body: {"text": "...", "extend_sec": 0}; extend_sec 0 restarts the countdown.
+func (s *Server) handleEditPending(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != "POST" {
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+		return
+	}
+	streamerName := r.URL.Query().Get("streamer")
+	msgID, _ := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	var req struct {
+		Text      string `json:"text"`
+		ExtendSec int    `json:"extend_sec"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, 400)
+		return
+	}
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" {
+		http.Error(w, `{"error":"text required"}`, 400)
+		return
+	}
+
+	s.mu.RLock()
+	rt, ok := s.streamers[streamerName]
+	var ctrl *controller.Controller
+	if ok {
+		ctrl = rt.ctrl
+	}
+	s.mu.RUnlock()
+
+	var editor string
+	if u := s.getUser(r); u != nil {
+		editor = u.Username
+	}
+	var prev string
+	ok = false
+	if ctrl != nil {
+		prev, ok = ctrl.EditPending(msgID, req.Text, editor, time.Duration(req.ExtendSec)*time.Second)
+	}
+	if !ok {
+		http.Error(w, `{"error":"message no longer pending"}`, 404)
+		return
+	}
+	s.audit(r, "edit_message", fmt.Sprintf("%s id=%d %q → %q", streamerName, msgID, prev, req.Text))
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "edited": msgID})
+}
+
+// handleInject sends operator-authored text through an output.
+// Body: {"text": "...", "immediate": false}; immediate skips the send delay.
+func (s *Server) handleInject(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	u := s.getUser(r)
+	if u == nil {
+		http.Error(w, `{"error":"unauthorized"}`, 401)
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+		return
+	}
+	streamerName := r.URL.Query().Get("streamer")
+	outputName := r.URL.Query().Get("output")
+	var req struct {
+		Text      string `json:"text"`
+		Immediate bool   `json:"immediate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, 400)
+		return
+	}
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" || outputName == "" {
+		http.Error(w, `{"error":"output and text required"}`, 400)
+		return
+	}
+
+	s.mu.RLock()
+	var roomID int64
+	for _, sc := range s.cfg.Streamers {
+		if sc.Name == streamerName {
+			roomID = sc.RoomID
+		}
+	}
+	var ctrl *controller.Controller
+	if rt := s.streamers[streamerName]; rt != nil {
+		ctrl = rt.ctrl
+	}
+	s.mu.RUnlock()
+
+	// Non-admin: only assigned rooms (users without assignments see all rooms)
+	if !u.IsAdmin {
+		if rooms, _ := s.store.GetUserRooms(u.ID); len(rooms) > 0 {
+			allowed := false
+			for _, rid := range rooms {
+				if rid == roomID {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				http.Error(w, `{"error":"forbidden"}`, 403)
+				return
+			}
+		}
+	}
+	if ctrl == nil {
+		http.Error(w, `{"error":"stream not running"}`, 409)
+		return
+	}
+
+	msgID, err := ctrl.Inject(outputName, req.Text, u.Username, req.Immediate)
+	if err != nil {
+		w.WriteHeader(400)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	s.audit(r, "inject_message", fmt.Sprintf("%s / %s immediate=%v %q", streamerName, outputName, req.Immediate, req.Text))
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "id": msgID})
+}
+
+// handleFailed resends (action=resend) or dismisses (action=dismiss) a
+// message from an output's failed list.
+func (s *Server) handleFailed(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != "POST" {
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+		return
+	}
+	streamerName := r.URL.Query().Get("streamer")
+	msgID, _ := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	action := r.URL.Query().Get("action")
+
+	s.mu.RLock()
+	var ctrl *controller.Controller
+	if rt := s.streamers[streamerName]; rt != nil {
+		ctrl = rt.ctrl
+	}
+	s.mu.RUnlock()
+	if ctrl == nil {
+		http.Error(w, `{"error":"stream not running"}`, 409)
+		return
+	}
+
+	var ok bool
+	switch action {
+	case "resend":
+		ok = ctrl.ResendFailed(msgID)
+	case "dismiss":
+		ok = ctrl.DismissFailed(msgID)
+	default:
+		http.Error(w, `{"error":"action must be resend or dismiss"}`, 400)
+		return
+	}
+	if !ok {
+		http.Error(w, `{"error":"failed message not found"}`, 404)
+		return
+	}
+	s.audit(r, action+"_failed", fmt.Sprintf("%s id=%d", streamerName, msgID))
+	json.NewEncoder(w).Encode(map[string]any{"ok": true})
+}
+
 // --- Admin handlers ---
 
 func (s *Server) handleAdminUsers(w http.ResponseWriter, r *http.Request) {
@@ -837,11 +1318,29 @@ func (s *Server) handleTranscriptDownload(w http.ResponseWriter, r *http.Request
 	}
 
 	filename := r.URL.Query().Get("file")
-	if filename == "" || filepath.Base(filename) != filename {
-		http.Error(w, "invalid filename", 400)
+	path, code := s.transcriptPath(u, filename)
+	if code != 0 {
+		http.Error(w, http.StatusText(code), code)
 		return
 	}
 
+	s.audit(r, "下载字幕", filename)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if strings.HasSuffix(filename, ".jsonl") {
+		w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	}
+	http.ServeFile(w, r, path)
+}
+
+// transcriptPath resolves a transcript file name for u. code is a non-zero
+// HTTP status when the name is invalid, not allowed or missing.
+func (s *Server) transcriptPath(u *auth.User, filename string) (path string, code int) {
+	if filename == "" || filepath.Base(filename) != filename {
+		return "", 400
+	}
+
 	// Non-admin: check room access
 	if !u.IsAdmin {
 		rooms, _ := s.store.GetUserRooms(u.ID)
@@ -854,25 +1353,99 @@ func (s *Server) handleTranscriptDownload(w http.ResponseWriter, r *http.Request
 			}
 		}
 		if !allowed {
-			http.Error(w, "forbidden", 403)
-			return
+			return "", 403
 		}
 	}
 
-	path := filepath.Join(s.transcriptDir, filename)
+	path = filepath.Join(s.transcriptDir, filename)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		http.Error(w, "not found", 404)
+		return "", 404
+	}
+	return path, 0
+}
+
+// handleTranscriptExport converts a transcript into subtitles.
+// GET ?file= lists its languages; ?file=&lang=&format=srt|vtt|ass[&shift=sec]
+// downloads one language.
+func (s *Server) handleTranscriptExport(w http.ResponseWriter, r *http.Request) {
+	u := s.getUser(r)
+	if u == nil {
+		http.Error(w, "unauthorized", 401)
 		return
 	}
 
-	s.audit(r, "下载字幕", filename)
-	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
-	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
-	http.ServeFile(w, r, path)
+	q := r.URL.Query()
+	filename := q.Get("file")
+	path, code := s.transcriptPath(u, filename)
+	if code != 0 {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+	entries, err := transcript.ReadFile(path)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	lang, format := q.Get("lang"), q.Get("format")
+	if lang == "" {
+		w.Header().Set("Content-Type", "application/json")
+		langs := transcript.Languages(entries)
+		if langs == nil {
+			langs = []string{}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"languages": langs, "formats": transcript.SubtitleFormats})
+		return
+	}
+	if !slices.Contains(transcript.SubtitleFormats, format) {
+		http.Error(w, "invalid format", 400)
+		return
+	}
+	shift, _ := strconv.ParseFloat(q.Get("shift"), 64)
+
+	cues := transcript.Cues(entries, lang, time.Duration(shift*float64(time.Second)))
+	if len(cues) == 0 {
+		http.Error(w, "no subtitles for "+lang, 404)
+		return
+	}
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	name := fmt.Sprintf("%s.%s.%s", base, lang, format)
+	contentType := map[string]string{
+		"srt": "application/x-subrip",
+		"vtt": "text/vtt",
+		"ass": "text/x-ssa",
+	}[format]
+
+	s.audit(r, "export_subtitles", name)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	transcript.WriteSubtitles(w, format, cues, base+" ("+lang+")")
 }
 
 // --- Bilibili Account Management ---
 
+// handleBotHealth returns per-bot send health (GET) or releases a
+// quarantined bot (POST ?name=&action=unquarantine).
+func (s *Server) handleBotHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case "GET":
+		json.NewEncoder(w).Encode(s.pool.Health())
+	case "POST":
+		name := r.URL.Query().Get("name")
+		if name == "" || r.URL.Query().Get("action") != "unquarantine" {
+			http.Error(w, `{"error":"name and action=unquarantine required"}`, 400)
+			return
+		}
+		s.pool.Unquarantine(name)
+		s.audit(r, "unquarantine_bot", name)
+		s.BroadcastStatus()
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	default:
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+	}
+}
+
 func (s *Server) handleBiliAccounts(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	accounts, err := s.store.ListBiliAccountSummaries()
@@ -956,7 +1529,7 @@ func (s *Server) handleBiliQRPoll(w http.ResponseWriter, r *http.Request) {
 			name = uname
 		}
 
-		acc, err := s.store.SaveBiliAccount(name, result.SESSDATA, result.BiliJCT, result.UID, 20, "")
+		acc, err := s.store.SaveBiliAccount(name, result.SESSDATA, result.BiliJCT, result.UID, 20, result.ExpiresAt, result.RefreshToken)
 		if err != nil {
 			json.NewEncoder(w).Encode(map[string]string{"status": "error", "error": err.Error()})
 			return
@@ -977,10 +1550,133 @@ func (s *Server) handleBiliQRPoll(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": result.Status})
 }
 
+// handleTwitchAccounts handles GET (list), POST (add/update) and DELETE
+// (?id=) of stored Twitch chat accounts.
+func (s *Server) handleTwitchAccounts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case "GET":
+		accounts, err := s.store.ListTwitchAccountSummaries()
+		if err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		if accounts == nil {
+			accounts = []auth.TwitchAccountSummary{}
+		}
+		json.NewEncoder(w).Encode(accounts)
+
+	case "POST":
+		var req struct {
+			Name  string `json:"name"`
+			Login string `json:"login"`
+			Token string `json:"token"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, `{"error":"invalid json"}`, 400)
+			return
+		}
+		if req.Login == "" || req.Token == "" {
+			http.Error(w, `{"error":"login and token required"}`, 400)
+			return
+		}
+		if req.Name == "" {
+			req.Name = "twitch:" + strings.ToLower(req.Login)
+		}
+		if b := s.pool.Get(req.Name); b != nil && b.Platform() != "twitch" {
+			http.Error(w, `{"error":"name already used by another account"}`, 409)
+			return
+		}
+		if err := s.store.SaveTwitchAccount(req.Name, req.Login, req.Token); err != nil {
+			w.WriteHeader(500)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		s.audit(r, "add_twitch_account", fmt.Sprintf("%s (%s)", req.Name, req.Login))
+		s.notifyAccountChange()
+		json.NewEncoder(w).Encode(map[string]string{"ok": "true"})
+
+	case "DELETE":
+		id, _ := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+		name, err := s.store.DeleteTwitchAccount(id)
+		if err != nil {
+			http.Error(w, `{"error":"not found"}`, 404)
+			return
+		}
+		s.audit(r, "delete_twitch_account", name)
+		s.notifyAccountChange()
+		json.NewEncoder(w).Encode(map[string]string{"ok": "true"})
+
+	default:
+		http.Error(w, "method not allowed", 405)
+	}
+}
+
 func (s *Server) notifyAccountChange() {
 	if s.onAccountChange != nil {
 		s.onAccountChange()
 	}
+	s.refreshInvalidAccounts()
+	s.BroadcastStatus()
+}
+
+// AccountValidityChanged is called by the account checker. It syncs the
+// bot pool, updates the panel warning and posts newly invalid accounts to
+// web.alert_webhook.
+func (s *Server) AccountValidityChanged(changes []auth.BiliValidityChange) {
+	s.notifyAccountChange()
+
+	s.mu.RLock()
+	webhook := s.cfg.Web.AlertWebhook
+	s.mu.RUnlock()
+	if webhook == "" {
+		return
+	}
+	for _, c := range changes {
+		if c.Valid {
+			continue
+		}
+		go postAlert(webhook, map[string]any{
+			"event":   "bili_account_invalid",
+			"account": c.Name,
+			"reason":  c.Reason,
+			"time":    time.Now().Format(time.RFC3339),
+			"text":    fmt.Sprintf("LiveSub: Bilibili account %s is no longer logged in (%s)", c.Name, c.Reason),
+		})
+	}
+}
+
+// refreshInvalidAccounts reloads the names of stored accounts marked invalid.
+func (s *Server) refreshInvalidAccounts() {
+	accounts, err := s.store.ListBiliAccountSummaries()
+	if err != nil {
+		slog.Error("list bili accounts", "err", err)
+		return
+	}
+	var invalid []string
+	for _, a := range accounts {
+		if !a.Valid {
+			invalid = append(invalid, a.Name)
+		}
+	}
+	s.mu.Lock()
+	s.invalidAccounts = invalid
+	s.mu.Unlock()
+}
+
+// postAlert POSTs payload as JSON to an alert webhook.
+func postAlert(url string, payload any) {
+	body, _ := json.Marshal(payload)
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		slog.Warn("alert webhook failed", "err", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		slog.Warn("alert webhook failed", "status", resp.StatusCode)
+	}
 }
 
 // --- Admin Streamer Management ---
@@ -1009,6 +1705,13 @@ func (s *Server) handleAdminStreamers(w http.ResponseWriter, r *http.Request) {
 		if req.Outputs == nil {
 			req.Outputs = []config.OutputConfig{}
 		}
+		for _, o := range req.Outputs {
+			if err := controller.ValidateModeration(o.Moderation); err != nil {
+				w.WriteHeader(400)
+				json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("output %s: %v", o.Name, err)})
+				return
+			}
+		}
 		// Update existing or add new
 		found := false
 		for i, sc := range s.cfg.Streamers {
@@ -1069,6 +1772,20 @@ func (s *Server) handleAdminStreamers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkAccountPlatforms rejects an output whose account pool names bots of
+// another platform. Sink outputs send without accounts.
+func (s *Server) checkAccountPlatforms(o config.OutputConfig) error {
+	if bot.IsSink(o.Platform) {
+		return nil
+	}
+	return o.CheckAccountPlatforms(func(name string) string {
+		if b := s.pool.Get(name); b != nil {
+			return b.Platform()
+		}
+		return ""
+	})
+}
+
 // handleAdminStreamerOutputs manages outputs for a specific streamer.
 func (s *Server) handleAdminStreamerOutputs(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1109,6 +1826,28 @@ func (s *Server) handleAdminStreamerOutputs(w http.ResponseWriter, r *http.Reque
 		if req.Platform == "" {
 			req.Platform = "bilibili"
 		}
+		if err := controller.ValidateModeration(req.Moderation); err != nil {
+			w.WriteHeader(400)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if req.Platform == "twitch" && req.Channel == "" {
+			http.Error(w, `{"error":"channel required for twitch"}`, 400)
+			return
+		}
+		if err := s.checkAccountPlatforms(req); err != nil {
+			w.WriteHeader(400)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if req.Platform == "overlay" && req.Overlay.Token == "" {
+			token, err := s.generateToken()
+			if err != nil {
+				http.Error(w, `{"error":"internal error"}`, 500)
+				return
+			}
+			req.Overlay.Token = token
+		}
 		found := false
 		for i, o := range sc.Outputs {
 			if o.Name == req.Name {
@@ -1203,6 +1942,25 @@ func (s *Server) handleMyAccounts(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(accts)
 }
 
+// handleTranslators returns the configured translation backend names
+// (default first) for the streamer/output translator selectors.
+func (s *Server) handleTranslators(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	tc := s.cfg.Translation
+	s.mu.RUnlock()
+
+	// Same names the translate.Registry resolves: the default backend under
+	// its provider name, then the named backends
+	names := []string{translate.ProviderName(tc.Provider)}
+	for _, b := range tc.Backends {
+		if !slices.Contains(names, b.Name) {
+			names = append(names, b.Name)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(names)
+}
+
 // handleMyStreamerOutputs lets authenticated users manage outputs for their assigned rooms.
 // Admins can access all rooms.
 func (s *Server) handleMyStreamerOutputs(w http.ResponseWriter, r *http.Request) {
@@ -1275,6 +2033,28 @@ func (s *Server) handleMyStreamerOutputs(w http.ResponseWriter, r *http.Request)
 		if req.Platform == "" {
 			req.Platform = "bilibili"
 		}
+		if err := controller.ValidateModeration(req.Moderation); err != nil {
+			w.WriteHeader(400)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if req.Platform == "twitch" && req.Channel == "" {
+			http.Error(w, `{"error":"channel required for twitch"}`, 400)
+			return
+		}
+		if err := s.checkAccountPlatforms(req); err != nil {
+			w.WriteHeader(400)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if req.Platform == "overlay" && req.Overlay.Token == "" {
+			token, err := s.generateToken()
+			if err != nil {
+				http.Error(w, `{"error":"internal error"}`, 500)
+				return
+			}
+			req.Overlay.Token = token
+		}
 		// Non-admin can only use their assigned accounts
 		if allowedAccounts != nil && req.Account != "" && !allowedAccounts[req.Account] {
 			http.Error(w, `{"error":"account not assigned to you"}`, 403)
@@ -1338,6 +2118,162 @@ func (s *Server) handleMyStreamerOutputs(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// --- Glossary ---
+
+// handleAdminGlossary handles GET (list by room_id), POST (add/update) and
+// DELETE (by id) for per-streamer glossary entries.
+func (s *Server) handleAdminGlossary(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case "GET":
+		roomID, _ := strconv.ParseInt(r.URL.Query().Get("room_id"), 10, 64)
+		if roomID == 0 {
+			http.Error(w, `{"error":"room_id required"}`, 400)
+			return
+		}
+		entries, err := s.store.ListGlossary(roomID)
+		if err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		if entries == nil {
+			entries = []auth.GlossaryEntry{}
+		}
+		json.NewEncoder(w).Encode(entries)
+
+	case "POST":
+		var req auth.GlossaryEntry
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, `{"error":"invalid json"}`, 400)
+			return
+		}
+		req.Source = strings.TrimSpace(req.Source)
+		req.Target = strings.TrimSpace(req.Target)
+		if req.RoomID == 0 || req.Source == "" || req.Target == "" {
+			http.Error(w, `{"error":"room_id, source and target required"}`, 400)
+			return
+		}
+		variants := req.Variants[:0]
+		for _, v := range req.Variants {
+			if v = strings.TrimSpace(v); v != "" {
+				variants = append(variants, v)
+			}
+		}
+		req.Variants = variants
+		entry, err := s.store.SaveGlossaryEntry(req)
+		if err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		s.audit(r, "save_glossary", fmt.Sprintf("room=%d %s → %s", entry.RoomID, entry.Source, entry.Target))
+		json.NewEncoder(w).Encode(entry)
+
+	case "DELETE":
+		id, _ := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+		if id == 0 {
+			http.Error(w, `{"error":"id required"}`, 400)
+			return
+		}
+		if err := s.store.DeleteGlossaryEntry(id); err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		s.audit(r, "delete_glossary", fmt.Sprintf("ID=%d", id))
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+
+	default:
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+	}
+}
+
+// --- Translation Cache ---
+
+// handleAdminTranslationCache handles GET (stats + search), PUT (edit a
+// translation), DELETE ?id= (remove one) and DELETE ?all=1 (purge).
+func (s *Server) handleAdminTranslationCache(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.cache == nil {
+		http.Error(w, `{"error":"translation cache disabled"}`, 404)
+		return
+	}
+	id, _ := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+
+	switch r.Method {
+	case "GET":
+		limit := 100
+		if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+			limit = n
+		}
+		entries, err := s.store.ListCachedTranslations(r.URL.Query().Get("q"), limit)
+		if err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		if entries == nil {
+			entries = []auth.CachedTranslation{}
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"stats":   s.cache.Stats(),
+			"entries": entries,
+		})
+
+	case "PUT":
+		var req struct {
+			Translated string `json:"translated"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, `{"error":"invalid json"}`, 400)
+			return
+		}
+		req.Translated = strings.TrimSpace(req.Translated)
+		if req.Translated == "" {
+			http.Error(w, `{"error":"translated required"}`, 400)
+			return
+		}
+		e, err := s.store.GetCachedTranslationByID(id)
+		if err != nil {
+			http.Error(w, `{"error":"entry not found"}`, 404)
+			return
+		}
+		if err := s.store.UpdateCachedTranslation(id, req.Translated); err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		s.cache.Invalidate(e.Text, e.SourceLang, e.TargetLang, e.Version)
+		s.audit(r, "edit_translation_cache", fmt.Sprintf("%s → %s (was %s)", e.Text, req.Translated, e.Translated))
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+
+	case "DELETE":
+		if r.URL.Query().Get("all") == "1" {
+			n, err := s.store.PurgeTranslationCache()
+			if err != nil {
+				http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+				return
+			}
+			s.cache.Purge()
+			s.audit(r, "purge_translation_cache", fmt.Sprintf("%d entries", n))
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "deleted": n})
+			return
+		}
+		e, err := s.store.GetCachedTranslationByID(id)
+		if err != nil {
+			http.Error(w, `{"error":"entry not found"}`, 404)
+			return
+		}
+		if err := s.store.DeleteCachedTranslation(id); err != nil {
+			http.Error(w, `{"error":"`+err.Error()+`"}`, 500)
+			return
+		}
+		s.cache.Invalidate(e.Text, e.SourceLang, e.TargetLang, e.Version)
+		s.audit(r, "delete_translation_cache", e.Text)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+
+	default:
+		http.Error(w, `{"error":"method not allowed"}`, 405)
+	}
+}
+
 // --- Audit ---
 
 func (s *Server) handleAdminAudit(w http.ResponseWriter, r *http.Request) {